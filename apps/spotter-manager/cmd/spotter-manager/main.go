@@ -8,28 +8,83 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
 	"spotter-manager/internal/handlers"
 )
 
+// clustersEnvVar names additional target clusters spotter-manager can fan
+// out RayService deploys to via the `cluster` query parameter, formatted as
+// a comma-separated list of `name=kubeconfigPath[@context]` entries.
+const clustersEnvVar = "SPOTTER_CLUSTERS"
+
+// templatesDirEnvVar names a directory of *.yaml.tmpl deployment templates
+// to load at startup for the /templates and /deploy endpoints.
+const templatesDirEnvVar = "SPOTTER_TEMPLATES_DIR"
+
+// loadTemplates loads templatesDirEnvVar's contents, if set. An unset value
+// starts spotter-manager with an empty TemplateRegistry.
+func loadTemplates() []handlers.Template {
+	dir := os.Getenv(templatesDirEnvVar)
+	if dir == "" {
+		return nil
+	}
+	templates, err := handlers.LoadTemplatesFromDir(dir)
+	if err != nil {
+		log.Fatalf("Failed to load deployment templates from %s: %v", dir, err)
+	}
+	return templates
+}
+
+// loadClusterConfigs parses clustersEnvVar into the ClusterConfig slice
+// ClusterRegistry expects. An unset or empty value registers no additional
+// clusters, leaving only the default client SetupKubernetesClient built.
+func loadClusterConfigs() []handlers.ClusterConfig {
+	raw := os.Getenv(clustersEnvVar)
+	if raw == "" {
+		return nil
+	}
+
+	var configs []handlers.ClusterConfig
+	for _, entry := range strings.Split(raw, ",") {
+		name, rest, ok := strings.Cut(entry, "=")
+		if !ok {
+			log.Printf("Ignoring malformed %s entry %q: expected name=kubeconfigPath[@context]", clustersEnvVar, entry)
+			continue
+		}
+		kubeconfigPath, kubeContext, _ := strings.Cut(rest, "@")
+		configs = append(configs, handlers.ClusterConfig{
+			Name:           name,
+			KubeconfigPath: kubeconfigPath,
+			Context:        kubeContext,
+		})
+	}
+	return configs
+}
+
 func main() {
 	// init  k8s client
 	k8sClient, err := handlers.SetupKubernetesClient()
 	if err != nil {
 		log.Fatalf("Kubernetes client initialization failed: %v", err)
 	}
+	clusters := handlers.NewClusterRegistry(k8sClient, loadClusterConfigs())
+	templates := handlers.NewTemplateRegistry(loadTemplates()...)
+	images := handlers.NewDistributionResolver(http.DefaultClient)
+	audit := handlers.NewMemoryAuditStore()
+
+	statusCache := handlers.NewStatusCache(k8sClient)
+	stopInformer := make(chan struct{})
+	defer close(stopInformer)
+	statusCache.Start(stopInformer)
 
-	mux := http.NewServeMux()
-	mux.HandleFunc("/", handlers.ServeFrontend)
-	mux.HandleFunc("/deploy", handlers.MakeDeployHandler(k8sClient))
-	mux.HandleFunc("/delete", handlers.MakeDeleteHandler(k8sClient))
-	mux.HandleFunc("/detect", handlers.DetectProxyHandler)
+	router := handlers.NewRouter(k8sClient, clusters, statusCache, templates, images, audit)
 
 	server := &http.Server{
 		Addr:    ":8080",
-		Handler: mux,
+		Handler: router,
 	}
 
 	stop := make(chan os.Signal, 1)
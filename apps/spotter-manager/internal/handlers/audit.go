@@ -0,0 +1,162 @@
+// apps/spotter-manager/internal/audit.go
+
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// requesterHeader names the header carrying the identity of whoever issued a
+// deploy or delete, populated by a reverse proxy (X-Forwarded-User) or an
+// mTLS-terminating ingress.
+const requesterHeader = "X-Forwarded-User"
+
+// AuditRecord captures one POST /services, DELETE /services/{name}, or POST
+// /deploy call for GET /history and GET /history/{uid}.
+type AuditRecord struct {
+	UID       string    `json:"uid,omitempty"`
+	Timestamp time.Time `json:"timestamp"`
+	Action    string    `json:"action"`
+	Requester string    `json:"requester,omitempty"`
+	Template  string    `json:"template,omitempty"`
+	Image     string    `json:"image,omitempty"`
+	Digest    string    `json:"digest,omitempty"`
+	Name      string    `json:"name,omitempty"`
+	Namespace string    `json:"namespace,omitempty"`
+	Success   bool      `json:"success"`
+	Error     string    `json:"error,omitempty"`
+}
+
+// AuditStore persists AuditRecords so GET /history and GET /history/{uid}
+// can answer who deployed or deleted what, and whether it succeeded, after
+// the fact. MemoryAuditStore is the in-process default; a durable
+// implementation (e.g. SQLite) can satisfy the same interface behind a
+// storage-specific build tag for persistence across restarts.
+type AuditStore interface {
+	Record(ctx context.Context, record AuditRecord) error
+	List(ctx context.Context) ([]AuditRecord, error)
+	Get(ctx context.Context, uid string) (AuditRecord, bool, error)
+}
+
+// MemoryAuditStore is an in-memory AuditStore, the default for
+// spotter-manager when no durable store is configured.
+type MemoryAuditStore struct {
+	mu      sync.RWMutex
+	records []AuditRecord
+}
+
+// NewMemoryAuditStore creates an empty MemoryAuditStore.
+func NewMemoryAuditStore() *MemoryAuditStore {
+	return &MemoryAuditStore{}
+}
+
+func (s *MemoryAuditStore) Record(ctx context.Context, record AuditRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.records = append(s.records, record)
+	return nil
+}
+
+func (s *MemoryAuditStore) List(ctx context.Context) ([]AuditRecord, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	list := make([]AuditRecord, len(s.records))
+	copy(list, s.records)
+	sort.Slice(list, func(i, j int) bool { return list[i].Timestamp.After(list[j].Timestamp) })
+	return list, nil
+}
+
+func (s *MemoryAuditStore) Get(ctx context.Context, uid string) (AuditRecord, bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, record := range s.records {
+		if record.UID == uid {
+			return record, true, nil
+		}
+	}
+	return AuditRecord{}, false, nil
+}
+
+// requesterFromRequest returns the caller identity from requesterHeader, or
+// empty if unset.
+func requesterFromRequest(r *http.Request) string {
+	return r.Header.Get(requesterHeader)
+}
+
+// splitImageDigest separates a resolved `repository@sha256:...` image
+// reference into its repository and digest, or returns image unchanged with
+// an empty digest if it isn't digest-pinned.
+func splitImageDigest(image string) (repository, digest string) {
+	if at := strings.Index(image, "@"); at != -1 {
+		return image[:at], image[at+1:]
+	}
+	return image, ""
+}
+
+// writeAuditRecord persists record via audit, stamping its Timestamp and
+// Requester from r. It is a no-op if audit is nil, and logs rather than
+// fails the caller's response if the store itself errors.
+func writeAuditRecord(r *http.Request, audit AuditStore, record AuditRecord) {
+	if audit == nil {
+		return
+	}
+	record.Timestamp = time.Now()
+	record.Requester = requesterFromRequest(r)
+	if err := audit.Record(r.Context(), record); err != nil {
+		logger.Error("Error recording audit log entry",
+			"request_id", requestIDFromContext(r.Context()),
+			"error", err.Error(),
+		)
+	}
+}
+
+// MakeListHistoryHandler creates an HTTP handler for GET /history. It lists
+// every AuditRecord, most recent first.
+func MakeListHistoryHandler(audit AuditStore) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		records, err := audit.List(r.Context())
+		if err != nil {
+			writeError(w, r, http.StatusInternalServerError,
+				fmt.Sprintf("Error listing audit history: %v", err),
+				"Internal server error listing audit history",
+			)
+			return
+		}
+		writeJSON(w, http.StatusOK, struct {
+			Items []AuditRecord `json:"items"`
+		}{Items: records})
+	}
+}
+
+// MakeGetHistoryHandler creates an HTTP handler for GET /history/{uid}. It
+// returns the AuditRecord for the deployed resource's UID, or 404 if no
+// record was made against that UID.
+func MakeGetHistoryHandler(audit AuditStore) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		uid := mux.Vars(r)["uid"]
+		record, ok, err := audit.Get(r.Context(), uid)
+		if err != nil {
+			writeError(w, r, http.StatusInternalServerError,
+				fmt.Sprintf("Error getting audit record %s: %v", uid, err),
+				"Internal server error getting audit record",
+			)
+			return
+		}
+		if !ok {
+			writeError(w, r, http.StatusNotFound,
+				fmt.Sprintf("No audit record for uid: %s", uid),
+				fmt.Sprintf("no audit record for uid '%s'", uid),
+			)
+			return
+		}
+		writeJSON(w, http.StatusOK, record)
+	}
+}
@@ -0,0 +1,109 @@
+//go:build sqlite
+
+// apps/spotter-manager/internal/audit_sqlite.go
+
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// SQLiteAuditStore is an AuditStore backed by a SQLite database, so audit
+// history survives a spotter-manager restart instead of living only in
+// memory. Build with `-tags sqlite` to include it.
+type SQLiteAuditStore struct {
+	db *sql.DB
+}
+
+// NewSQLiteAuditStore opens (and, if needed, creates) the audit_records
+// table in the SQLite database at path.
+func NewSQLiteAuditStore(path string) (*SQLiteAuditStore, error) {
+	db, err := sql.Open("sqlite3", path)
+	if err != nil {
+		return nil, fmt.Errorf("opening SQLite audit database %q: %w", path, err)
+	}
+	const schema = `
+CREATE TABLE IF NOT EXISTS audit_records (
+	uid        TEXT,
+	timestamp  DATETIME NOT NULL,
+	action     TEXT NOT NULL,
+	requester  TEXT,
+	template   TEXT,
+	image      TEXT,
+	digest     TEXT,
+	name       TEXT,
+	namespace  TEXT,
+	success    BOOLEAN NOT NULL,
+	error      TEXT
+)`
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("creating audit_records table: %w", err)
+	}
+	return &SQLiteAuditStore{db: db}, nil
+}
+
+func (s *SQLiteAuditStore) Record(ctx context.Context, record AuditRecord) error {
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO audit_records
+			(uid, timestamp, action, requester, template, image, digest, name, namespace, success, error)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		record.UID, record.Timestamp, record.Action, record.Requester, record.Template,
+		record.Image, record.Digest, record.Name, record.Namespace, record.Success, record.Error,
+	)
+	if err != nil {
+		return fmt.Errorf("inserting audit record: %w", err)
+	}
+	return nil
+}
+
+func (s *SQLiteAuditStore) List(ctx context.Context) ([]AuditRecord, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT uid, timestamp, action, requester, template, image, digest, name, namespace, success, error
+		 FROM audit_records ORDER BY timestamp DESC`,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("querying audit records: %w", err)
+	}
+	defer rows.Close()
+	return scanAuditRecords(rows)
+}
+
+func (s *SQLiteAuditStore) Get(ctx context.Context, uid string) (AuditRecord, bool, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT uid, timestamp, action, requester, template, image, digest, name, namespace, success, error
+		 FROM audit_records WHERE uid = ? ORDER BY timestamp DESC LIMIT 1`,
+		uid,
+	)
+	if err != nil {
+		return AuditRecord{}, false, fmt.Errorf("querying audit record %q: %w", uid, err)
+	}
+	defer rows.Close()
+	records, err := scanAuditRecords(rows)
+	if err != nil {
+		return AuditRecord{}, false, err
+	}
+	if len(records) == 0 {
+		return AuditRecord{}, false, nil
+	}
+	return records[0], true, nil
+}
+
+func scanAuditRecords(rows *sql.Rows) ([]AuditRecord, error) {
+	var records []AuditRecord
+	for rows.Next() {
+		var record AuditRecord
+		if err := rows.Scan(
+			&record.UID, &record.Timestamp, &record.Action, &record.Requester, &record.Template,
+			&record.Image, &record.Digest, &record.Name, &record.Namespace, &record.Success, &record.Error,
+		); err != nil {
+			return nil, fmt.Errorf("scanning audit record row: %w", err)
+		}
+		records = append(records, record)
+	}
+	return records, rows.Err()
+}
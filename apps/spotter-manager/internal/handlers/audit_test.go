@@ -0,0 +1,124 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+)
+
+func TestMemoryAuditStoreRecordAndGet(t *testing.T) {
+	store := NewMemoryAuditStore()
+	ctx := context.Background()
+
+	if err := store.Record(ctx, AuditRecord{UID: "uid-1", Action: "deploy", Success: true}); err != nil {
+		t.Fatalf("Unexpected error recording: %v", err)
+	}
+	if err := store.Record(ctx, AuditRecord{UID: "uid-2", Action: "delete", Success: false, Error: "boom"}); err != nil {
+		t.Fatalf("Unexpected error recording: %v", err)
+	}
+
+	record, ok, err := store.Get(ctx, "uid-2")
+	if err != nil || !ok {
+		t.Fatalf("Expected to find uid-2, ok=%v err=%v", ok, err)
+	}
+	if record.Error != "boom" {
+		t.Errorf("Expected error 'boom', got %q", record.Error)
+	}
+
+	if _, ok, err := store.Get(ctx, "does-not-exist"); err != nil || ok {
+		t.Errorf("Expected no record for an unknown uid, ok=%v err=%v", ok, err)
+	}
+
+	records, err := store.List(ctx)
+	if err != nil || len(records) != 2 {
+		t.Fatalf("Expected 2 records, got %d (err: %v)", len(records), err)
+	}
+}
+
+func TestMakeListHistoryHandler(t *testing.T) {
+	store := NewMemoryAuditStore()
+	store.Record(context.Background(), AuditRecord{UID: "uid-1", Action: "deploy", Success: true})
+
+	handler := MakeListHistoryHandler(store)
+	req, err := http.NewRequest(http.MethodGet, "/history", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", rr.Code, rr.Body.String())
+	}
+	var body struct {
+		Items []AuditRecord `json:"items"`
+	}
+	if err := json.Unmarshal(rr.Body.Bytes(), &body); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if len(body.Items) != 1 || body.Items[0].UID != "uid-1" {
+		t.Errorf("Expected one record with uid 'uid-1', got %+v", body.Items)
+	}
+}
+
+func TestMakeGetHistoryHandler(t *testing.T) {
+	store := NewMemoryAuditStore()
+	store.Record(context.Background(), AuditRecord{UID: "uid-1", Action: "deploy", Success: true})
+
+	router := mux.NewRouter()
+	router.HandleFunc("/history/{uid}", MakeGetHistoryHandler(store)).Methods(http.MethodGet)
+
+	t.Run("Found", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodGet, "/history/uid-1", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+
+		if rr.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d. Body: %s", rr.Code, rr.Body.String())
+		}
+		var record AuditRecord
+		if err := json.Unmarshal(rr.Body.Bytes(), &record); err != nil {
+			t.Fatalf("Failed to unmarshal response: %v", err)
+		}
+		if record.UID != "uid-1" {
+			t.Errorf("Expected uid 'uid-1', got %q", record.UID)
+		}
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodGet, "/history/missing", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+
+		if rr.Code != http.StatusNotFound {
+			t.Fatalf("Expected status 404, got %d. Body: %s", rr.Code, rr.Body.String())
+		}
+	})
+}
+
+func TestSplitImageDigest(t *testing.T) {
+	tests := []struct {
+		image          string
+		expectedRepo   string
+		expectedDigest string
+	}{
+		{image: "team/app:latest", expectedRepo: "team/app:latest", expectedDigest: ""},
+		{image: "team/app@sha256:abcd", expectedRepo: "team/app", expectedDigest: "sha256:abcd"},
+	}
+	for _, tt := range tests {
+		repo, digest := splitImageDigest(tt.image)
+		if repo != tt.expectedRepo || digest != tt.expectedDigest {
+			t.Errorf("splitImageDigest(%q) = (%q, %q), want (%q, %q)", tt.image, repo, digest, tt.expectedRepo, tt.expectedDigest)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+// apps/spotter-manager/internal/cluster.go
+
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// clusterHealthCheckTimeout bounds how long a newly-registered cluster's
+// connectivity probe may take before it is reported unreachable.
+const clusterHealthCheckTimeout = 5 * time.Second
+
+// ClusterConfig names a Kubernetes API server spotter-manager can target in
+// addition to the cluster SetupKubernetesClient connects to at startup.
+// KubeconfigPath/Context are resolved the same way `kubectl --context`
+// would, so operators can reuse their existing kubeconfig files.
+type ClusterConfig struct {
+	Name           string
+	KubeconfigPath string
+	Context        string
+}
+
+// ClusterRegistry resolves a `cluster` query parameter to a dynamic.Interface,
+// building and health-probing clients lazily on first use. This lets a
+// single spotter-manager fan out RayService deployments across dev/staging/
+// prod clusters instead of being pinned to whatever SetupKubernetesClient
+// picked at startup.
+type ClusterRegistry struct {
+	defaultClient dynamic.Interface
+	configs       map[string]ClusterConfig
+
+	mu      sync.Mutex
+	clients map[string]dynamic.Interface
+}
+
+// NewClusterRegistry creates a ClusterRegistry. defaultClient is returned
+// when a request omits the `cluster` query parameter; configs describes the
+// additional named clusters that may be selected explicitly.
+func NewClusterRegistry(defaultClient dynamic.Interface, configs []ClusterConfig) *ClusterRegistry {
+	byName := make(map[string]ClusterConfig, len(configs))
+	for _, c := range configs {
+		byName[c.Name] = c
+	}
+	return &ClusterRegistry{
+		defaultClient: defaultClient,
+		configs:       byName,
+		clients:       make(map[string]dynamic.Interface),
+	}
+}
+
+// Get resolves name to a dynamic.Interface. An empty name returns the
+// default client. A non-empty name is built and health-probed against its
+// kubeconfig on first use and cached for subsequent calls; an unreachable or
+// unregistered cluster returns a descriptive error rather than a generic
+// connection failure once the request reaches the Kubernetes API.
+func (r *ClusterRegistry) Get(ctx context.Context, name string) (dynamic.Interface, error) {
+	if name == "" {
+		return r.defaultClient, nil
+	}
+
+	r.mu.Lock()
+	if client, ok := r.clients[name]; ok {
+		r.mu.Unlock()
+		return client, nil
+	}
+	r.mu.Unlock()
+
+	cfg, ok := r.configs[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown cluster %q", name)
+	}
+
+	restConfig, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+		&clientcmd.ClientConfigLoadingRules{ExplicitPath: cfg.KubeconfigPath},
+		&clientcmd.ConfigOverrides{CurrentContext: cfg.Context},
+	).ClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf("loading kubeconfig for cluster %q: %w", name, err)
+	}
+
+	client, err := dynamic.NewForConfig(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("creating dynamic client for cluster %q: %w", name, err)
+	}
+
+	probeCtx, cancel := context.WithTimeout(ctx, clusterHealthCheckTimeout)
+	defer cancel()
+	if _, err := client.Resource(rayServiceGVR).Namespace(metav1.NamespaceAll).List(probeCtx, metav1.ListOptions{Limit: 1}); err != nil {
+		return nil, fmt.Errorf("cluster %q is unreachable: %w", name, err)
+	}
+
+	r.mu.Lock()
+	r.clients[name] = client
+	r.mu.Unlock()
+
+	logger.Info("Registered new target cluster", "cluster", name)
+	return client, nil
+}
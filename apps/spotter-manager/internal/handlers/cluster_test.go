@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+// writeKubeconfig writes a minimal kubeconfig pointing at server to a file
+// under t.TempDir() and returns its path.
+func writeKubeconfig(t *testing.T, server string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "kubeconfig")
+	contents := `apiVersion: v1
+kind: Config
+clusters:
+- cluster:
+    server: ` + server + `
+    insecure-skip-tls-verify: true
+  name: test
+contexts:
+- context:
+    cluster: test
+    user: test
+  name: test
+current-context: test
+users:
+- name: test
+  user: {}
+`
+	if err := os.WriteFile(path, []byte(contents), 0o600); err != nil {
+		t.Fatalf("Failed to write kubeconfig: %v", err)
+	}
+	return path
+}
+
+func TestClusterRegistryGetDefault(t *testing.T) {
+	defaultClient := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme())
+	registry := NewClusterRegistry(defaultClient, nil)
+
+	client, err := registry.Get(context.Background(), "")
+	if err != nil {
+		t.Fatalf("Unexpected error resolving empty cluster name: %v", err)
+	}
+	if client != defaultClient {
+		t.Error("Expected an empty cluster name to resolve to the default client")
+	}
+}
+
+func TestClusterRegistryGetUnknownCluster(t *testing.T) {
+	registry := NewClusterRegistry(dynamicfake.NewSimpleDynamicClient(runtime.NewScheme()), nil)
+
+	if _, err := registry.Get(context.Background(), "does-not-exist"); err == nil {
+		t.Error("Expected an error resolving an unregistered cluster name")
+	}
+}
+
+func TestClusterRegistryGetUnreachableCluster(t *testing.T) {
+	// Port 1 is reserved and nothing listens on it, so the health probe's
+	// List call fails with a connection error instead of hanging for the
+	// full clusterHealthCheckTimeout.
+	kubeconfig := writeKubeconfig(t, "https://127.0.0.1:1")
+	registry := NewClusterRegistry(dynamicfake.NewSimpleDynamicClient(runtime.NewScheme()), []ClusterConfig{
+		{Name: "unreachable", KubeconfigPath: kubeconfig},
+	})
+
+	_, err := registry.Get(context.Background(), "unreachable")
+	if err == nil {
+		t.Fatal("Expected an error resolving a cluster whose health probe fails")
+	}
+	if !strings.Contains(err.Error(), `cluster "unreachable" is unreachable`) {
+		t.Errorf("Expected an 'is unreachable' error, got: %v", err)
+	}
+}
+
+func TestClusterRegistryGetBuildsFromKubeconfig(t *testing.T) {
+	var listRequests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/apis/ray.io/v1alpha1/rayservices" {
+			http.NotFound(w, r)
+			return
+		}
+		listRequests++
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"apiVersion":"ray.io/v1alpha1","kind":"RayServiceList","items":[]}`))
+	}))
+	defer server.Close()
+
+	kubeconfig := writeKubeconfig(t, server.URL)
+	registry := NewClusterRegistry(dynamicfake.NewSimpleDynamicClient(runtime.NewScheme()), []ClusterConfig{
+		{Name: "staging", KubeconfigPath: kubeconfig},
+	})
+
+	client, err := registry.Get(context.Background(), "staging")
+	if err != nil {
+		t.Fatalf("Unexpected error building client from kubeconfig: %v", err)
+	}
+	if client == nil {
+		t.Fatal("Expected a non-nil client")
+	}
+	if listRequests != 1 {
+		t.Errorf("Expected the health probe to issue 1 List request, got %d", listRequests)
+	}
+
+	// A second call should reuse the cached client rather than probing again.
+	if _, err := registry.Get(context.Background(), "staging"); err != nil {
+		t.Fatalf("Unexpected error on cached lookup: %v", err)
+	}
+	if listRequests != 1 {
+		t.Errorf("Expected the cached client to skip a second health probe, got %d List requests", listRequests)
+	}
+}
@@ -3,28 +3,27 @@
 package handlers
 
 import (
-	"bytes"
+	"encoding/json"
 	"fmt"
-	"io"
-	"log"
 	"net/http"
-	"os"
-	"text/template"
-	"time"
 
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
-	"k8s.io/apimachinery/pkg/util/yaml"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/rest"
 )
 
-const (
-	rayServiceName      = "spotter-ray-service"
-	rayServiceNamespace = "spotter"
-)
+const defaultServiceNamespace = "spotter"
+
+var rayServiceGVR = schema.GroupVersionResource{
+	Group:    "ray.io",
+	Version:  "v1alpha1",
+	Resource: "rayservices",
+}
 
 // SetupKubernetesClient initializes and returns a Kubernetes dynamic client.
 func SetupKubernetesClient() (dynamic.Interface, error) {
@@ -36,7 +35,7 @@ func SetupKubernetesClient() (dynamic.Interface, error) {
 	if err != nil {
 		return nil, fmt.Errorf("Failed to create dynamic client: %w", err)
 	}
-	log.Println("Kubernetes dynamic client initialized successfully.")
+	logger.Info("Kubernetes dynamic client initialized successfully.")
 	return client, nil
 }
 
@@ -50,341 +49,406 @@ func ServeFrontend(w http.ResponseWriter, r *http.Request) {
 	http.ServeFile(w, r, "web/index.html") // relative path to binary in container
 }
 
-// MakeDeployHandler creates an HTTP handler for deploying the RayService.
-func MakeDeployHandler(client dynamic.Interface) http.HandlerFunc {
-	return func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodPost {
-			http.Error(w, "Only POST requests are allowed.", http.StatusMethodNotAllowed)
-			return
-		}
+// ResourceRequests captures the compute resources requested for a RayService
+// head/worker pod, mirroring the subset of the Kubernetes resource model the
+// RayService manifest builder in manifest.go cares about.
+type ResourceRequests struct {
+	CPU    string `json:"cpu,omitempty"`
+	Memory string `json:"memory,omitempty"`
+}
 
-		queryParams := r.URL.Query()
-		dockerImage := queryParams.Get("dockerimage")
-		if dockerImage == "" {
-			http.Error(w, "Missing required query parameter: dockerimage", http.StatusBadRequest)
-			return
+// ServiceRequest is the JSON body accepted by POST /services. It describes a
+// single tenant's desired RayService.
+type ServiceRequest struct {
+	Name      string            `json:"name"`
+	Namespace string            `json:"namespace,omitempty"`
+	Image     string            `json:"image"`
+	Replicas  int32             `json:"replicas,omitempty"`
+	Resources ResourceRequests  `json:"resources,omitempty"`
+	Labels    map[string]string `json:"labels,omitempty"`
+}
+
+// ServiceResponse is the JSON representation of a RayService returned by the
+// /services endpoints.
+type ServiceResponse struct {
+	Name      string            `json:"name"`
+	Namespace string            `json:"namespace"`
+	UID       string            `json:"uid,omitempty"`
+	Image     string            `json:"image,omitempty"`
+	Replicas  int32             `json:"replicas,omitempty"`
+	Labels    map[string]string `json:"labels,omitempty"`
+	// DryRun is true if this response describes a server-side dry-run apply
+	// that was validated and admitted but not persisted.
+	DryRun bool `json:"dryRun,omitempty"`
+}
+
+// ServiceList is the JSON envelope returned by GET /services.
+type ServiceList struct {
+	Items []ServiceResponse `json:"items"`
+}
+
+// errorEnvelope is the structured error body returned by the /services
+// subsystem on failure, in place of a flattened plain-text message. Reason
+// and Causes are populated when the underlying error is a Kubernetes
+// *errors.StatusError, so a client can act on the failure (e.g. highlight
+// the offending field) instead of pattern-matching the message string.
+type errorEnvelope struct {
+	Error  string               `json:"error"`
+	Reason metav1.StatusReason  `json:"reason,omitempty"`
+	Causes []metav1.StatusCause `json:"causes,omitempty"`
+}
+
+// writeError writes a structured JSON error envelope and logs the underlying
+// cause, tagged with the request's ID if one has been assigned.
+func writeError(w http.ResponseWriter, r *http.Request, status int, logMsg string, clientMsg string) {
+	logger.Error(logMsg, "request_id", requestIDFromContext(r.Context()))
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(errorEnvelope{Error: clientMsg})
+}
+
+// writeKubernetesError writes an errorEnvelope for a failed Kubernetes API
+// call, unpacking the StatusError's reason and causes when err carries them
+// so the client sees the same structured detail kubectl would.
+func writeKubernetesError(w http.ResponseWriter, r *http.Request, status int, logMsg string, clientMsg string, err error) {
+	logger.Error(logMsg, "request_id", requestIDFromContext(r.Context()), "error", err.Error())
+	env := errorEnvelope{Error: clientMsg}
+	if statusErr, ok := err.(*errors.StatusError); ok {
+		env.Reason = statusErr.ErrStatus.Reason
+		if statusErr.ErrStatus.Details != nil {
+			env.Causes = statusErr.ErrStatus.Details.Causes
 		}
-		log.Printf("Attempting to deploy RayService with Docker image: %s", dockerImage)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(env)
+}
+
+// writeJSON writes v as a JSON response body with the given status code.
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
 
-		params := map[string]string{
-			"DockerImage": dockerImage,
+// serviceResponseFromUnstructured extracts the fields spotter-manager cares
+// about from a RayService object returned by the Kubernetes API.
+func serviceResponseFromUnstructured(obj *unstructured.Unstructured) ServiceResponse {
+	resp := ServiceResponse{
+		Name:      obj.GetName(),
+		Namespace: obj.GetNamespace(),
+		UID:       string(obj.GetUID()),
+		Labels:    obj.GetLabels(),
+	}
+	containers, found, _ := unstructured.NestedSlice(
+		obj.Object, "spec", "rayClusterConfig", "headGroupSpec", "template", "spec", "containers",
+	)
+	if found && len(containers) > 0 {
+		if containerMap, ok := containers[0].(map[string]interface{}); ok {
+			if image, ok := containerMap["image"].(string); ok {
+				resp.Image = image
+			}
 		}
+	}
+	return resp
+}
 
-		// path to the template relative to the running binary
-		templatePath := "configs/rayservice-template.yaml"
-		if _, err := os.Stat(templatePath); os.IsNotExist(err) {
-			log.Printf("Error: RayService manifest template file not found at %s", templatePath)
-			http.Error(
-				w,
-				"Internal server error: RayService manifest template file missing",
-				http.StatusInternalServerError,
-			)
+// MakeCreateServiceHandler creates an HTTP handler for POST /services. It
+// server-side applies a new RayService scoped to the requester's namespace,
+// so multiple tenants can each run their own detection service. Passing
+// ?dryRun=server validates and admits the apply without persisting it, so
+// callers can preview the result (e.g. to surface validation errors in a
+// form) before committing to a real deploy. Passing ?cluster=<name> targets
+// a cluster registered with clusters instead of its default client. Every
+// apply attempt, successful or not, is recorded via audit.
+func MakeCreateServiceHandler(clusters *ClusterRegistry, audit AuditStore) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req ServiceRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, r, http.StatusBadRequest, fmt.Sprintf("Error decoding service request body: %v", err), "Invalid request body")
 			return
 		}
-		templateBytes, err := os.ReadFile(templatePath)
-		if err != nil {
-			log.Printf(
-				"Error reading RayService manifest template file '%s': %v",
-				templatePath,
-				err,
-			)
-			http.Error(
-				w,
-				"Internal server error reading RayService manifest template",
-				http.StatusInternalServerError,
-			)
+		if req.Name == "" {
+			writeError(w, r, http.StatusBadRequest, "Missing required field: name", "Missing required field: name")
 			return
 		}
-		tmpl, err := template.New("rayservice").Parse(string(templateBytes))
-		if err != nil {
-			log.Printf("Error parsing RayService manifest template: %v", err)
-			http.Error(
-				w,
-				"Internal server error parsing RayService manifest template",
-				http.StatusInternalServerError,
-			)
+		if req.Image == "" {
+			writeError(w, r, http.StatusBadRequest, "Missing required field: image", "Missing required field: image")
 			return
 		}
-
-		var buf bytes.Buffer
-		if err := tmpl.Execute(&buf, params); err != nil {
-			log.Printf("Error populating RayService manifest template: %v", err)
-			http.Error(
-				w,
-				"Internal server error populating RayService manifest template",
-				http.StatusInternalServerError,
-			)
-			return
+		if req.Namespace == "" {
+			req.Namespace = defaultServiceNamespace
 		}
 
-		// log the generated manifest for debugging
-		log.Printf("Generated RayService manifest:\n%s", buf.String())
-
-		// k8s yaml decoder
-		obj := &unstructured.Unstructured{}
-		decoder := yaml.NewYAMLOrJSONDecoder(bytes.NewReader(buf.Bytes()), 4096)
-		if err := decoder.Decode(&obj); err != nil {
-			log.Printf(
-				"Error decoding populated RayService manifest: %v\nYAML:\n%s",
-				err,
-				buf.String(),
-			)
-			http.Error(
-				w,
-				"Internal server error decoding populated RayService manifest",
-				http.StatusInternalServerError,
-			)
-			return
-		}
-		if obj.Object == nil {
-			log.Printf(
-				"Error: decoded RayService manifest is nil. Check template output.\nYAML:\n%s",
-				buf.String(),
-			)
-			http.Error(
-				w,
-				"Internal server error: failed to parse decoded populated RayService manifest",
-				http.StatusInternalServerError,
+		cluster := r.URL.Query().Get("cluster")
+		client, err := clusters.Get(r.Context(), cluster)
+		if err != nil {
+			writeError(
+				w, r,
+				http.StatusBadGateway,
+				fmt.Sprintf("Error resolving target cluster %q: %v", cluster, err),
+				fmt.Sprintf("failed to reach target cluster: %s", err.Error()),
 			)
 			return
 		}
 
-		rayGVR := schema.GroupVersionResource{
-			Group:    "ray.io",
-			Version:  "v1alpha1",
-			Resource: "rayservices",
-		}
+		dryRun := r.URL.Query().Get("dryRun") == "server"
 
-		log.Printf(
-			"Applying RayService configuration %s/%s...",
-			rayServiceNamespace,
-			rayServiceName,
+		logger.Info("Attempting to deploy RayService",
+			"request_id", requestIDFromContext(r.Context()),
+			"cluster", cluster,
+			"namespace", req.Namespace,
+			"name", req.Name,
+			"image", req.Image,
+			"dry_run", dryRun,
 		)
 
+		obj, err := buildRayServiceManifest(req)
+		if err != nil {
+			writeError(w, r, http.StatusInternalServerError, fmt.Sprintf("Error building RayService manifest: %v", err), "Internal server error building RayService manifest")
+			return
+		}
+
 		applyOptions := metav1.ApplyOptions{
 			FieldManager: "spotter-manager",
 			Force:        true,
 		}
-		appliedObj, err := client.Resource(rayGVR).Namespace(rayServiceNamespace).Apply(
+		if dryRun {
+			applyOptions.DryRun = []string{metav1.DryRunAll}
+		}
+		appliedObj, err := client.Resource(rayServiceGVR).Namespace(req.Namespace).Apply(
 			r.Context(),
-			rayServiceName,
+			req.Name,
 			obj,
 			applyOptions,
 		)
-
 		if err != nil {
-			log.Printf(
-				"Error applying RayService %s/%s: %v",
-				rayServiceNamespace,
-				rayServiceName,
-				err,
-			)
-			http.Error(
-				w,
-				fmt.Sprintf(
-					"Internal server error: failed to apply RayService '%s' in namespace '%s': %s",
-					rayServiceName,
-					rayServiceNamespace,
-					err.Error(),
-				),
+			recordRayApplyError(err)
+			writeAuditRecord(r, audit, AuditRecord{
+				Action:    "deploy",
+				Image:     req.Image,
+				Name:      req.Name,
+				Namespace: req.Namespace,
+				Success:   false,
+				Error:     err.Error(),
+			})
+			writeKubernetesError(
+				w, r,
 				http.StatusInternalServerError,
+				fmt.Sprintf("Error applying RayService %s/%s: %v", req.Namespace, req.Name, err),
+				fmt.Sprintf("failed to apply RayService '%s' in namespace '%s': %s", req.Name, req.Namespace, err.Error()),
+				err,
 			)
 			return
 		}
 
-		log.Printf(
-			"Successfully applied RayService configuration %s/%s (UID: %s)",
-			rayServiceNamespace,
-			rayServiceName,
-			appliedObj.GetUID(),
-		)
-		w.WriteHeader(http.StatusOK) // 200
-		fmt.Fprintf(
-			w,
-			"RayService '%s' applied successfully in namespace '%s'",
-			rayServiceName,
-			rayServiceNamespace,
+		logger.Info("Successfully applied RayService",
+			"request_id", requestIDFromContext(r.Context()),
+			"namespace", req.Namespace,
+			"name", req.Name,
+			"uid", string(appliedObj.GetUID()),
+			"dry_run", dryRun,
 		)
+		writeAuditRecord(r, audit, AuditRecord{
+			UID:       string(appliedObj.GetUID()),
+			Action:    "deploy",
+			Image:     req.Image,
+			Name:      req.Name,
+			Namespace: req.Namespace,
+			Success:   true,
+		})
+		resp := serviceResponseFromUnstructured(appliedObj)
+		resp.DryRun = dryRun
+		writeJSON(w, http.StatusOK, resp)
 	}
 }
 
-// MakeDeleteHandler creates an HTTP handler for deleting the RayService.
-func MakeDeleteHandler(client dynamic.Interface) http.HandlerFunc {
+// MakeListServicesHandler creates an HTTP handler for GET /services. If a
+// `name` query parameter is present it behaves like a single-resource
+// lookup; otherwise it lists every RayService across all namespaces this
+// client can see.
+func MakeListServicesHandler(client dynamic.Interface) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodPost {
-			http.Error(w, "Only POST requests are allowed.", http.StatusMethodNotAllowed)
+		if name := r.URL.Query().Get("name"); name != "" {
+			getServiceByName(w, r, client, name)
 			return
 		}
 
-		log.Printf("Attempting to delete RayService %s/%s", rayServiceNamespace, rayServiceName)
-
-		rayGVR := schema.GroupVersionResource{
-			Group:    "ray.io",
-			Version:  "v1alpha1",
-			Resource: "rayservices",
-		}
-
-		err := client.Resource(rayGVR).Namespace(rayServiceNamespace).Delete(
-			r.Context(),
-			rayServiceName,
-			metav1.DeleteOptions{},
-		)
+		list, err := client.Resource(rayServiceGVR).Namespace(metav1.NamespaceAll).List(r.Context(), metav1.ListOptions{})
 		if err != nil {
-			if errors.IsNotFound(err) {
-				log.Printf(
-					"RayService %s/%s not found, no deletion will occur",
-					rayServiceNamespace,
-					rayServiceName,
-				)
-			} else {
-				// Handle other errors as internal server errors
-				log.Printf(
-					"Error deleting RayService %s/%s: %v",
-					rayServiceNamespace,
-					rayServiceName,
-					err,
-				)
-				http.Error(
-					w,
-					fmt.Sprintf(
-						"Internal server error: failed to delete RayService '%s' in namespace '%s': %s",
-						rayServiceName,
-						rayServiceNamespace,
-						err.Error(),
-					),
-					http.StatusInternalServerError,
-				)
-				return
-			}
-		}
-
-		if err == nil {
-			log.Printf(
-				"Successfully initiated deletion for RayService %s/%s",
-				rayServiceNamespace,
-				rayServiceName,
+			writeError(
+				w, r,
+				http.StatusInternalServerError,
+				fmt.Sprintf("Error listing RayServices: %v", err),
+				fmt.Sprintf("failed to list RayServices: %s", err.Error()),
 			)
+			return
 		}
 
-		w.WriteHeader(http.StatusOK)
-		var responseMsg string
-		if err != nil && errors.IsNotFound(err) {
-			responseMsg = fmt.Sprintf(
-				"RayService '%s' in namespace '%s' did not exist, no deletion occurred",
-				rayServiceName,
-				rayServiceNamespace,
-			)
-		} else {
-			responseMsg = fmt.Sprintf(
-				"RayService '%s' deleted successfully from namespace '%s'",
-				rayServiceName,
-				rayServiceNamespace,
-			)
+		resp := ServiceList{Items: make([]ServiceResponse, 0, len(list.Items))}
+		for i := range list.Items {
+			resp.Items = append(resp.Items, serviceResponseFromUnstructured(&list.Items[i]))
 		}
-		fmt.Fprint(w, responseMsg)
+		writeJSON(w, http.StatusOK, resp)
 	}
 }
 
-// ProxyHandler holds dependencies for the detect proxy
-type ProxyHandler struct {
-	TargetURL string
-	Client    *http.Client // Allow injecting a client for testing/timeouts
-}
-
-// NewProxyHandler creates a new ProxyHandler
-// If targetURLOverride is empty, it constructs the default RayService URL.
-func NewProxyHandler(targetURLOverride string) *ProxyHandler {
-	targetURL := targetURLOverride
-	if targetURL == "" {
-		targetURL = fmt.Sprintf(
-			"http://%s-head-svc.%s.svc.cluster.local:8000/detect",
-			rayServiceName,
-			rayServiceNamespace,
-		)
-	}
-	return &ProxyHandler{
-		TargetURL: targetURL,
-		Client: &http.Client{
-			Timeout: 60 * time.Second,
-		},
+// MakeGetServiceHandler creates an HTTP handler for GET /services/{name}. It
+// describes the current status of a single RayService.
+func MakeGetServiceHandler(client dynamic.Interface) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		name := mux.Vars(r)["name"]
+		getServiceByName(w, r, client, name)
 	}
 }
 
-// ServeHTTP forwards requests to the configured TargetURL
-func (h *ProxyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		http.Error(w, "Only POST requests are allowed.", http.StatusMethodNotAllowed)
-		return
+// getServiceByName looks up a RayService by name in the default service
+// namespace, or in the namespace supplied via the `namespace` query
+// parameter, and writes it as a ServiceResponse.
+func getServiceByName(w http.ResponseWriter, r *http.Request, client dynamic.Interface, name string) {
+	namespace := r.URL.Query().Get("namespace")
+	if namespace == "" {
+		namespace = defaultServiceNamespace
 	}
 
-	bodyBytes, err := io.ReadAll(r.Body)
+	obj, err := client.Resource(rayServiceGVR).Namespace(namespace).Get(r.Context(), name, metav1.GetOptions{})
 	if err != nil {
-		log.Printf("Error reading request body: %v", err)
-		http.Error(w, "Error reading request", http.StatusBadRequest)
+		if errors.IsNotFound(err) {
+			writeError(
+				w, r,
+				http.StatusNotFound,
+				fmt.Sprintf("RayService %s/%s not found", namespace, name),
+				fmt.Sprintf("RayService '%s' not found in namespace '%s'", name, namespace),
+			)
+			return
+		}
+		writeError(
+			w, r,
+			http.StatusInternalServerError,
+			fmt.Sprintf("Error getting RayService %s/%s: %v", namespace, name, err),
+			fmt.Sprintf("failed to get RayService '%s' in namespace '%s': %s", name, namespace, err.Error()),
+		)
 		return
 	}
-	r.Body.Close() // Close the original request body
 
-	proxyReq, err := http.NewRequestWithContext(
-		r.Context(),
-		"POST",
-		h.TargetURL,
-		bytes.NewReader(bodyBytes),
-	)
-	if err != nil {
-		log.Printf("Error creating proxy request: %v", err)
-		http.Error(w, "Internal server error creating proxy request", http.StatusInternalServerError)
-		return
-	}
-	proxyReq.Header = r.Header.Clone()
+	writeJSON(w, http.StatusOK, serviceResponseFromUnstructured(obj))
+}
 
-	resp, err := h.Client.Do(proxyReq)
-	if err != nil {
-		log.Printf("Error forwarding request to target %s: %v", h.TargetURL, err)
-		http.Error(
-			w,
-			fmt.Sprintf(
-				"Bad gateway: failed to communicate with detection service at %s: %v",
-				h.TargetURL,
-				err,
-			),
-			http.StatusBadGateway,
-		)
-		return
-	}
-	defer resp.Body.Close()
+// MakeDeleteServiceHandler creates an HTTP handler for DELETE /services/{name}.
+// Passing ?cluster=<name> targets a cluster registered with clusters instead
+// of its default client. Every delete attempt, successful or not, is
+// recorded via audit.
+func MakeDeleteServiceHandler(clusters *ClusterRegistry, audit AuditStore) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		name := mux.Vars(r)["name"]
+		namespace := r.URL.Query().Get("namespace")
+		if namespace == "" {
+			namespace = defaultServiceNamespace
+		}
 
-	for key, values := range resp.Header {
-		for _, value := range values {
-			w.Header().Add(key, value)
+		cluster := r.URL.Query().Get("cluster")
+		client, err := clusters.Get(r.Context(), cluster)
+		if err != nil {
+			writeError(
+				w, r,
+				http.StatusBadGateway,
+				fmt.Sprintf("Error resolving target cluster %q: %v", cluster, err),
+				fmt.Sprintf("failed to reach target cluster: %s", err.Error()),
+			)
+			return
 		}
-	}
 
-	w.WriteHeader(resp.StatusCode)
+		logger.Info("Attempting to delete RayService",
+			"request_id", requestIDFromContext(r.Context()),
+			"cluster", cluster,
+			"namespace", namespace,
+			"name", name,
+		)
 
-	if _, err := io.Copy(w, resp.Body); err != nil {
-		log.Printf("Error copying response body from target %s: %v", h.TargetURL, err)
-		if !headerWritten(w) {
-			http.Error(
-				w,
-				"Internal server error reading backend response",
+		err = client.Resource(rayServiceGVR).Namespace(namespace).Delete(r.Context(), name, metav1.DeleteOptions{})
+		if err != nil {
+			if errors.IsNotFound(err) {
+				writeAuditRecord(r, audit, AuditRecord{
+					Action:    "delete",
+					Name:      name,
+					Namespace: namespace,
+					Success:   false,
+					Error:     err.Error(),
+				})
+				writeError(
+					w, r,
+					http.StatusNotFound,
+					fmt.Sprintf("RayService %s/%s not found, no deletion will occur", namespace, name),
+					fmt.Sprintf("RayService '%s' not found in namespace '%s'", name, namespace),
+				)
+				return
+			}
+			recordRayApplyError(err)
+			writeAuditRecord(r, audit, AuditRecord{
+				Action:    "delete",
+				Name:      name,
+				Namespace: namespace,
+				Success:   false,
+				Error:     err.Error(),
+			})
+			writeKubernetesError(
+				w, r,
 				http.StatusInternalServerError,
+				fmt.Sprintf("Error deleting RayService %s/%s: %v", namespace, name, err),
+				fmt.Sprintf("failed to delete RayService '%s' in namespace '%s': %s", name, namespace, err.Error()),
+				err,
 			)
+			return
 		}
-		return
-	}
 
-	log.Printf("Successfully proxied detection request to %s", h.TargetURL)
+		logger.Info("Successfully initiated deletion for RayService",
+			"request_id", requestIDFromContext(r.Context()),
+			"namespace", namespace,
+			"name", name,
+		)
+		writeAuditRecord(r, audit, AuditRecord{
+			Action:    "delete",
+			Name:      name,
+			Namespace: namespace,
+			Success:   true,
+		})
+		w.WriteHeader(http.StatusNoContent)
+	}
 }
 
-// Helper function to check if the response header has been written
-// This prevents writing multiple headers.
-func headerWritten(w http.ResponseWriter) bool {
-	// check the status code
-	if ww, ok := w.(interface{ Status() int }); ok {
-		return ww.Status() != 0
-	}
-	// Fallback check
-	// simple heuristic: check if a common header exists
-	return w.Header().Get("Date") != ""
+// NewRouter builds the spotter-manager REST subsystem: a CRUD broker over
+// RayService resources so each tenant can deploy and manage their own
+// detection service, modeled after container-runtime HTTP APIs. Every route
+// is wrapped with LoggingMiddleware for request IDs, structured access
+// logs, and Prometheus metrics; /metrics exposes those metrics for scraping.
+// Create, delete, and POST /deploy accept a `cluster` query parameter
+// resolved against clusters, so a single spotter-manager can fan out across
+// clusters; the remaining /services routes read through client, the default
+// cluster's client.
+// GET /templates and POST /deploy expose templates as a general,
+// parameterized alternative to /services for resource kinds beyond
+// RayService; POST /deploy resolves an "image" parameter against images so
+// the applied manifest always pins an immutable digest. GET /detect/stream
+// upgrades to a WebSocket and proxies streaming detection frames to and from
+// the RayService Serve backend. Every POST /services, DELETE
+// /services/{name}, and POST /deploy call that reaches the Kubernetes API is
+// recorded to audit; GET /history and GET /history/{uid} read it back.
+func NewRouter(client dynamic.Interface, clusters *ClusterRegistry, statusCache *StatusCache, templates *TemplateRegistry, images ImageResolver, audit AuditStore) *mux.Router {
+	r := mux.NewRouter()
+	r.Use(LoggingMiddleware)
+	r.HandleFunc("/", ServeFrontend).Methods(http.MethodGet)
+	r.HandleFunc("/services", MakeCreateServiceHandler(clusters, audit)).Methods(http.MethodPost)
+	r.HandleFunc("/services", MakeListServicesHandler(client)).Methods(http.MethodGet)
+	r.HandleFunc("/services/{name}", MakeGetServiceHandler(client)).Methods(http.MethodGet)
+	r.HandleFunc("/services/{name}", MakeDeleteServiceHandler(clusters, audit)).Methods(http.MethodDelete)
+	r.HandleFunc("/status", MakeStatusHandler(statusCache)).Methods(http.MethodGet)
+	r.HandleFunc("/status/watch", MakeStatusWatchHandler(statusCache)).Methods(http.MethodGet)
+	r.HandleFunc("/templates", MakeListTemplatesHandler(templates)).Methods(http.MethodGet)
+	r.HandleFunc("/deploy", MakeTemplateDeployHandler(templates, clusters, images, audit)).Methods(http.MethodPost)
+	r.HandleFunc("/detect", NewProxyHandler("").ServeHTTP).Methods(http.MethodPost)
+	r.HandleFunc("/detect/stream", NewStreamingProxyHandler("").ServeHTTP).Methods(http.MethodGet)
+	r.HandleFunc("/history", MakeListHistoryHandler(audit)).Methods(http.MethodGet)
+	r.HandleFunc("/history/{uid}", MakeGetHistoryHandler(audit)).Methods(http.MethodGet)
+	r.Handle("/metrics", promhttp.Handler()).Methods(http.MethodGet)
+	return r
 }
@@ -0,0 +1,117 @@
+// apps/spotter-manager/internal/manifest.go
+
+package handlers
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const defaultReplicas int32 = 1
+
+// rayServiceManifest is a typed, in-code mirror of the subset of the
+// ray.io/v1alpha1 RayService spec spotter-manager populates. Building it as
+// a Go struct (converted via runtime.DefaultUnstructuredConverter) means
+// field paths and types are checked at compile time instead of by a
+// text/template rendering an arbitrary YAML string.
+type rayServiceManifest struct {
+	APIVersion string             `json:"apiVersion"`
+	Kind       string             `json:"kind"`
+	Metadata   rayServiceMetadata `json:"metadata"`
+	Spec       rayServiceSpec     `json:"spec"`
+}
+
+type rayServiceMetadata struct {
+	Name      string            `json:"name"`
+	Namespace string            `json:"namespace"`
+	Labels    map[string]string `json:"labels,omitempty"`
+}
+
+type rayServiceSpec struct {
+	RayClusterConfig rayClusterConfig `json:"rayClusterConfig"`
+}
+
+type rayClusterConfig struct {
+	HeadGroupSpec headGroupSpec `json:"headGroupSpec"`
+	Replicas      int32         `json:"replicas"`
+}
+
+type headGroupSpec struct {
+	Template podTemplateSpec `json:"template"`
+}
+
+type podTemplateSpec struct {
+	Spec podSpec `json:"spec"`
+}
+
+type podSpec struct {
+	Containers []containerSpec `json:"containers"`
+}
+
+type containerSpec struct {
+	Name      string              `json:"name"`
+	Image     string              `json:"image"`
+	Resources *containerResources `json:"resources,omitempty"`
+}
+
+type containerResources struct {
+	Requests map[string]string `json:"requests,omitempty"`
+}
+
+// buildRayServiceManifest constructs the unstructured RayService object for
+// req entirely in code, so required fields and their types are validated by
+// the Go compiler rather than by parsing a rendered template string.
+func buildRayServiceManifest(req ServiceRequest) (*unstructured.Unstructured, error) {
+	replicas := req.Replicas
+	if replicas <= 0 {
+		replicas = defaultReplicas
+	}
+
+	var resources *containerResources
+	if req.Resources.CPU != "" || req.Resources.Memory != "" {
+		requests := make(map[string]string, 2)
+		if req.Resources.CPU != "" {
+			requests["cpu"] = req.Resources.CPU
+		}
+		if req.Resources.Memory != "" {
+			requests["memory"] = req.Resources.Memory
+		}
+		resources = &containerResources{Requests: requests}
+	}
+
+	manifest := rayServiceManifest{
+		APIVersion: rayServiceGVR.GroupVersion().String(),
+		Kind:       "RayService",
+		Metadata: rayServiceMetadata{
+			Name:      req.Name,
+			Namespace: req.Namespace,
+			Labels:    req.Labels,
+		},
+		Spec: rayServiceSpec{
+			RayClusterConfig: rayClusterConfig{
+				Replicas: replicas,
+				HeadGroupSpec: headGroupSpec{
+					Template: podTemplateSpec{
+						Spec: podSpec{
+							Containers: []containerSpec{
+								{
+									Name:      "ray-head",
+									Image:     req.Image,
+									Resources: resources,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	converted, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&manifest)
+	if err != nil {
+		return nil, fmt.Errorf("converting RayService manifest to unstructured: %w", err)
+	}
+	return &unstructured.Unstructured{Object: converted}, nil
+}
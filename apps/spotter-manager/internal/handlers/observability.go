@@ -0,0 +1,176 @@
+// apps/spotter-manager/internal/observability.go
+
+package handlers
+
+import (
+	"bufio"
+	"context"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// logger is the process-wide structured logger. Every handler and
+// middleware in this package logs through it instead of the stdlib log
+// package, so operators get consistent JSON records with request IDs.
+var logger = slog.New(slog.NewJSONHandler(os.Stdout, nil))
+
+type contextKey string
+
+const (
+	requestIDHeader                = "X-Request-ID"
+	requestIDContextKey contextKey = "requestID"
+)
+
+var (
+	httpRequestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "spotter_http_requests_total",
+		Help: "Total HTTP requests served by spotter-manager, labeled by method, route, and status code.",
+	}, []string{"method", "route", "status"})
+
+	httpRequestDurationSeconds = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "spotter_http_request_duration_seconds",
+		Help:    "HTTP request latency in seconds, labeled by method and route.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "route"})
+
+	rayApplyErrorsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "spotter_ray_apply_errors_total",
+		Help: "Total errors returned by the Kubernetes API while applying or deleting RayService manifests, labeled by reason.",
+	}, []string{"reason"})
+
+	detectProxyBytesTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "spotter_detect_proxy_bytes_total",
+		Help: "Bytes proxied through the /detect endpoint, labeled by direction (request or response).",
+	}, []string{"direction"})
+)
+
+// statusRecorder wraps an http.ResponseWriter to capture the status code
+// that was actually written, so middleware can log and record it.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(code int) {
+	r.status = code
+	r.ResponseWriter.WriteHeader(code)
+}
+
+func (r *statusRecorder) Flush() {
+	if f, ok := r.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func (r *statusRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	h, ok := r.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, http.ErrNotSupported
+	}
+	return h.Hijack()
+}
+
+// Status returns the recorded status code, defaulting to 200 if the handler
+// never explicitly called WriteHeader.
+func (r *statusRecorder) Status() int {
+	if r.status == 0 {
+		return http.StatusOK
+	}
+	return r.status
+}
+
+// LoggingMiddleware assigns a request ID to every request (reusing one
+// supplied via the X-Request-ID header, if present), emits a structured
+// JSON access log line, and records Prometheus request metrics.
+func LoggingMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID := r.Header.Get(requestIDHeader)
+		if requestID == "" {
+			requestID = uuid.NewString()
+		}
+		w.Header().Set(requestIDHeader, requestID)
+		r = r.WithContext(context.WithValue(r.Context(), requestIDContextKey, requestID))
+
+		rec := &statusRecorder{ResponseWriter: w}
+		start := time.Now()
+		next.ServeHTTP(rec, r)
+		duration := time.Since(start)
+
+		route := r.URL.Path
+		if matchedRoute := mux.CurrentRoute(r); matchedRoute != nil {
+			if tmpl, err := matchedRoute.GetPathTemplate(); err == nil {
+				route = tmpl
+			}
+		}
+		status := rec.Status()
+
+		logger.Info("http_request",
+			"request_id", requestID,
+			"method", r.Method,
+			"path", route,
+			"status", status,
+			"duration_ms", duration.Milliseconds(),
+		)
+
+		statusLabel := strconv.Itoa(status)
+		httpRequestsTotal.WithLabelValues(r.Method, route, statusLabel).Inc()
+		httpRequestDurationSeconds.WithLabelValues(r.Method, route).Observe(duration.Seconds())
+	})
+}
+
+// requestIDFromContext returns the request ID assigned by LoggingMiddleware,
+// or "" if none is present (e.g. in a unit test calling a handler directly).
+func requestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDContextKey).(string)
+	return id
+}
+
+// classifyKubernetesError returns a short, metric-label-friendly reason for
+// a Kubernetes API error, using apimachinery's error classification instead
+// of matching on error strings.
+func classifyKubernetesError(err error) string {
+	switch {
+	case err == nil:
+		return ""
+	case apierrors.IsNotFound(err):
+		return "not_found"
+	case apierrors.IsAlreadyExists(err):
+		return "already_exists"
+	case apierrors.IsConflict(err):
+		return "conflict"
+	case apierrors.IsInvalid(err):
+		return "invalid"
+	case apierrors.IsTimeout(err):
+		return "timeout"
+	case apierrors.IsServerTimeout(err):
+		return "server_timeout"
+	case apierrors.IsForbidden(err):
+		return "forbidden"
+	case apierrors.IsUnauthorized(err):
+		return "unauthorized"
+	case apierrors.IsTooManyRequests(err):
+		return "too_many_requests"
+	default:
+		return "unknown"
+	}
+}
+
+// recordRayApplyError records a spotter_ray_apply_errors_total observation
+// for a failed apply/delete against the Kubernetes API.
+func recordRayApplyError(err error) {
+	reason := classifyKubernetesError(err)
+	if reason == "" {
+		reason = "unknown"
+	}
+	rayApplyErrorsTotal.WithLabelValues(reason).Inc()
+}
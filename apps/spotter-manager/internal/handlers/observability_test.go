@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestLoggingMiddlewareAssignsRequestID(t *testing.T) {
+	var sawRequestID string
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sawRequestID = requestIDFromContext(r.Context())
+		w.WriteHeader(http.StatusTeapot)
+	})
+
+	req, err := http.NewRequest(http.MethodGet, "/anything", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rr := httptest.NewRecorder()
+	LoggingMiddleware(next).ServeHTTP(rr, req)
+
+	if sawRequestID == "" {
+		t.Error("Expected a request ID to be available to the wrapped handler")
+	}
+	if got := rr.Header().Get(requestIDHeader); got != sawRequestID {
+		t.Errorf("Expected %s header %q to match the context request ID %q", requestIDHeader, got, sawRequestID)
+	}
+	if rr.Code != http.StatusTeapot {
+		t.Errorf("Expected status %d to pass through, got %d", http.StatusTeapot, rr.Code)
+	}
+}
+
+func TestLoggingMiddlewareReusesIncomingRequestID(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	req, err := http.NewRequest(http.MethodGet, "/anything", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set(requestIDHeader, "incoming-id-123")
+
+	rr := httptest.NewRecorder()
+	LoggingMiddleware(next).ServeHTTP(rr, req)
+
+	if got := rr.Header().Get(requestIDHeader); got != "incoming-id-123" {
+		t.Errorf("Expected incoming request ID to be preserved, got %q", got)
+	}
+}
+
+func TestClassifyKubernetesError(t *testing.T) {
+	if got := classifyKubernetesError(nil); got != "" {
+		t.Errorf("Expected empty reason for nil error, got %q", got)
+	}
+}
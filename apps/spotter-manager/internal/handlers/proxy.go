@@ -0,0 +1,217 @@
+// apps/spotter-manager/internal/proxy.go
+
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"time"
+)
+
+const (
+	defaultMaxRequestBodyBytes = 32 << 20 // 32MiB
+	defaultProxyTimeout        = 60 * time.Second
+
+	proxyBufferPoolSize = 64
+	proxyBufferSize     = 32 * 1024
+)
+
+// sharedProxyTransport is reused across every ProxyHandler so outbound
+// connections to the RayService head pod are pooled and kept alive instead
+// of being dialed fresh per request.
+var sharedProxyTransport = &http.Transport{
+	MaxIdleConns:        100,
+	MaxIdleConnsPerHost: 20,
+	IdleConnTimeout:     90 * time.Second,
+}
+
+// proxyBufferPool is shared across every ProxyHandler for the header- and
+// small-body-copy paths the reverse proxy performs internally.
+var proxyBufferPool = NewSizedBufferPool(proxyBufferPoolSize, proxyBufferSize)
+
+// SizedBufferPool is a bounded pool of fixed-size byte buffers implementing
+// httputil.BufferPool. Unlike sync.Pool it never grows unbounded under
+// bursty load: once poolSize idle buffers are already held, Put simply drops
+// the buffer for the GC to reclaim.
+type SizedBufferPool struct {
+	pool chan []byte
+	size int
+}
+
+// NewSizedBufferPool creates a SizedBufferPool holding up to poolSize
+// buffers of bufferSize bytes each.
+func NewSizedBufferPool(poolSize, bufferSize int) *SizedBufferPool {
+	return &SizedBufferPool{
+		pool: make(chan []byte, poolSize),
+		size: bufferSize,
+	}
+}
+
+// Get returns a buffer from the pool, allocating a new one if it is empty.
+func (p *SizedBufferPool) Get() []byte {
+	select {
+	case b := <-p.pool:
+		return b
+	default:
+		return make([]byte, p.size)
+	}
+}
+
+// Put returns a buffer to the pool, dropping it if the pool is full.
+func (p *SizedBufferPool) Put(b []byte) {
+	select {
+	case p.pool <- b:
+	default:
+	}
+}
+
+// ProxyHandlerOptions configures the behavior of a ProxyHandler.
+type ProxyHandlerOptions struct {
+	// MaxRequestBodyBytes caps the size of the incoming request body. Zero
+	// disables the cap.
+	MaxRequestBodyBytes int64
+	// RequestTimeout bounds the lifetime of the proxied request, including
+	// streaming the response back to the client. Zero disables the timeout.
+	RequestTimeout time.Duration
+}
+
+func defaultProxyHandlerOptions() ProxyHandlerOptions {
+	return ProxyHandlerOptions{
+		MaxRequestBodyBytes: defaultMaxRequestBodyBytes,
+		RequestTimeout:      defaultProxyTimeout,
+	}
+}
+
+// ProxyOption mutates a ProxyHandlerOptions; pass zero or more to
+// NewProxyHandler to override the defaults.
+type ProxyOption func(*ProxyHandlerOptions)
+
+// WithMaxRequestBodyBytes overrides the request body size cap.
+func WithMaxRequestBodyBytes(n int64) ProxyOption {
+	return func(o *ProxyHandlerOptions) { o.MaxRequestBodyBytes = n }
+}
+
+// WithRequestTimeout overrides the per-request timeout.
+func WithRequestTimeout(d time.Duration) ProxyOption {
+	return func(o *ProxyHandlerOptions) { o.RequestTimeout = d }
+}
+
+// ProxyHandler streams detection requests through to the RayService Serve
+// endpoint without buffering the full request or response into memory, so
+// the backend can stream incremental detection results (chunked transfer,
+// SSE, or an upgraded connection) straight back to the browser.
+type ProxyHandler struct {
+	TargetURL string
+	Options   ProxyHandlerOptions
+
+	reverseProxy *httputil.ReverseProxy
+}
+
+// NewProxyHandler creates a new ProxyHandler.
+// If targetURLOverride is empty, it constructs the default RayService URL.
+func NewProxyHandler(targetURLOverride string, opts ...ProxyOption) *ProxyHandler {
+	targetURL := targetURLOverride
+	if targetURL == "" {
+		targetURL = fmt.Sprintf(
+			"http://spotter-ray-service-head-svc.%s.svc.cluster.local:8000/detect",
+			defaultServiceNamespace,
+		)
+	}
+
+	options := defaultProxyHandlerOptions()
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	h := &ProxyHandler{
+		TargetURL: targetURL,
+		Options:   options,
+	}
+
+	target, err := url.Parse(targetURL)
+	if err != nil {
+		// TargetURL is either the hardcoded default above or supplied by the
+		// operator at startup; a malformed value is a configuration bug we
+		// want to fail loudly on rather than silently proxy nowhere.
+		logger.Error("Invalid proxy target URL", "target_url", targetURL, "error", err.Error())
+		panic(fmt.Sprintf("Invalid proxy target URL %q: %v", targetURL, err))
+	}
+
+	h.reverseProxy = &httputil.ReverseProxy{
+		Director: func(req *http.Request) {
+			req.URL.Scheme = target.Scheme
+			req.URL.Host = target.Host
+			req.URL.Path = target.Path
+			req.URL.RawQuery = target.RawQuery
+			req.Host = target.Host
+		},
+		Transport: sharedProxyTransport,
+		// Flush immediately on every write so chunked/SSE responses reach
+		// the browser incrementally instead of waiting for a full buffer.
+		FlushInterval: -1,
+		BufferPool:    proxyBufferPool,
+		ModifyResponse: func(resp *http.Response) error {
+			resp.Body = &countingReadCloser{ReadCloser: resp.Body, direction: "response"}
+			return nil
+		},
+		ErrorHandler: func(w http.ResponseWriter, r *http.Request, err error) {
+			logger.Error("Error forwarding request to target",
+				"request_id", requestIDFromContext(r.Context()),
+				"target_url", h.TargetURL,
+				"error", err.Error(),
+			)
+			http.Error(
+				w,
+				fmt.Sprintf(
+					"Bad gateway: failed to communicate with detection service at %s: %v",
+					h.TargetURL,
+					err,
+				),
+				http.StatusBadGateway,
+			)
+		},
+	}
+
+	return h
+}
+
+// countingReadCloser records every byte read through it against
+// spotter_detect_proxy_bytes_total, labeled by direction.
+type countingReadCloser struct {
+	io.ReadCloser
+	direction string
+}
+
+func (c *countingReadCloser) Read(p []byte) (int, error) {
+	n, err := c.ReadCloser.Read(p)
+	if n > 0 {
+		detectProxyBytesTotal.WithLabelValues(c.direction).Add(float64(n))
+	}
+	return n, err
+}
+
+// ServeHTTP forwards the request to the configured TargetURL, streaming the
+// request and response bodies through rather than buffering them.
+func (h *ProxyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Only POST requests are allowed.", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if h.Options.MaxRequestBodyBytes > 0 {
+		r.Body = http.MaxBytesReader(w, r.Body, h.Options.MaxRequestBodyBytes)
+	}
+	r.Body = &countingReadCloser{ReadCloser: r.Body, direction: "request"}
+
+	if h.Options.RequestTimeout > 0 {
+		ctx, cancel := context.WithTimeout(r.Context(), h.Options.RequestTimeout)
+		defer cancel()
+		r = r.WithContext(ctx)
+	}
+
+	h.reverseProxy.ServeHTTP(w, r)
+}
@@ -0,0 +1,229 @@
+// apps/spotter-manager/internal/registry.go
+
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// acceptManifestTypes lists the manifest media types spotter-manager can
+// resolve a digest from, covering both the OCI and legacy Docker Distribution
+// manifest and manifest-list formats.
+const acceptManifestTypes = "application/vnd.oci.image.manifest.v1+json," +
+	"application/vnd.oci.image.index.v1+json," +
+	"application/vnd.docker.distribution.manifest.v2+json," +
+	"application/vnd.docker.distribution.manifest.list.v2+json"
+
+// defaultRegistryHost is the registry an image reference resolves against
+// when it names no host of its own, matching Docker's own convention.
+const defaultRegistryHost = "registry-1.docker.io"
+
+// ImageResolver pins a possibly-mutable image reference (e.g. a `:latest`
+// tag) to an immutable `repository@sha256:...` reference, so a deploy is
+// reproducible even if the tag is later reassigned. MakeTemplateDeployHandler
+// takes one as a collaborator so tests can inject a resolver stubbed against
+// an httptest.Server instead of a real registry.
+type ImageResolver interface {
+	Resolve(ctx context.Context, image string) (string, error)
+}
+
+// DistributionResolver resolves images against a real OCI Distribution v2
+// registry: it issues a HEAD against the image's manifest endpoint, follows a
+// Bearer WWW-Authenticate challenge if the registry requires one, and reads
+// the resulting digest back off the Docker-Content-Digest header.
+type DistributionResolver struct {
+	HTTPClient *http.Client
+}
+
+// NewDistributionResolver creates a DistributionResolver that issues requests
+// with httpClient, or http.DefaultClient if nil.
+func NewDistributionResolver(httpClient *http.Client) *DistributionResolver {
+	return &DistributionResolver{HTTPClient: httpClient}
+}
+
+func (r *DistributionResolver) httpClient() *http.Client {
+	if r.HTTPClient != nil {
+		return r.HTTPClient
+	}
+	return http.DefaultClient
+}
+
+// Resolve implements ImageResolver. An image already pinned to a digest
+// (`repo@sha256:...`) is returned unchanged.
+func (r *DistributionResolver) Resolve(ctx context.Context, image string) (string, error) {
+	ref, err := parseImageReference(image)
+	if err != nil {
+		return "", err
+	}
+	if ref.isDigest {
+		return image, nil
+	}
+
+	scheme := "https"
+	if strings.HasPrefix(ref.host, "localhost") || strings.HasPrefix(ref.host, "127.0.0.1") {
+		scheme = "http"
+	}
+	manifestURL := fmt.Sprintf("%s://%s/v2/%s/manifests/%s", scheme, ref.host, ref.repository, ref.reference)
+
+	digest, err := r.headManifest(ctx, manifestURL, "")
+	if err != nil {
+		return "", fmt.Errorf("resolving image %q: %w", image, err)
+	}
+	return fmt.Sprintf("%s/%s@%s", ref.host, ref.repository, digest), nil
+}
+
+// headManifest issues a HEAD against manifestURL, retrying once with a
+// fetched Bearer token if the registry challenges the first attempt.
+func (r *DistributionResolver) headManifest(ctx context.Context, manifestURL, bearerToken string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, manifestURL, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Accept", acceptManifestTypes)
+	if bearerToken != "" {
+		req.Header.Set("Authorization", "Bearer "+bearerToken)
+	}
+
+	resp, err := r.httpClient().Do(req)
+	if err != nil {
+		return "", fmt.Errorf("contacting registry: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized && bearerToken == "" {
+		realm, service, scope, challengeErr := parseBearerChallenge(resp.Header.Get("Www-Authenticate"))
+		if challengeErr != nil {
+			return "", fmt.Errorf("registry requires auth but sent an unsupported challenge: %w", challengeErr)
+		}
+		token, err := r.fetchToken(ctx, realm, service, scope)
+		if err != nil {
+			return "", fmt.Errorf("fetching registry auth token: %w", err)
+		}
+		return r.headManifest(ctx, manifestURL, token)
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return "", fmt.Errorf("manifest not found")
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected registry response: %s", resp.Status)
+	}
+
+	digest := resp.Header.Get("Docker-Content-Digest")
+	if digest == "" {
+		return "", fmt.Errorf("registry response missing Docker-Content-Digest header")
+	}
+	return digest, nil
+}
+
+// fetchToken requests a Bearer token from realm per the Docker token auth
+// spec, passing service and scope as query parameters when the challenge
+// supplied them.
+func (r *DistributionResolver) fetchToken(ctx context.Context, realm, service, scope string) (string, error) {
+	tokenURL, err := url.Parse(realm)
+	if err != nil {
+		return "", fmt.Errorf("parsing token realm %q: %w", realm, err)
+	}
+	q := tokenURL.Query()
+	if service != "" {
+		q.Set("service", service)
+	}
+	if scope != "" {
+		q.Set("scope", scope)
+	}
+	tokenURL.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, tokenURL.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := r.httpClient().Do(req)
+	if err != nil {
+		return "", fmt.Errorf("contacting token endpoint: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("token endpoint returned %s", resp.Status)
+	}
+
+	var body struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("decoding token response: %w", err)
+	}
+	if body.Token != "" {
+		return body.Token, nil
+	}
+	if body.AccessToken != "" {
+		return body.AccessToken, nil
+	}
+	return "", fmt.Errorf("token endpoint response contained neither token nor access_token")
+}
+
+// parseBearerChallenge extracts realm, service, and scope from a
+// `Bearer realm="...",service="...",scope="..."` WWW-Authenticate header.
+func parseBearerChallenge(header string) (realm, service, scope string, err error) {
+	if !strings.HasPrefix(header, "Bearer ") {
+		return "", "", "", fmt.Errorf("unsupported WWW-Authenticate scheme: %q", header)
+	}
+	for _, part := range strings.Split(strings.TrimPrefix(header, "Bearer "), ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			continue
+		}
+		value = strings.Trim(value, `"`)
+		switch key {
+		case "realm":
+			realm = value
+		case "service":
+			service = value
+		case "scope":
+			scope = value
+		}
+	}
+	if realm == "" {
+		return "", "", "", fmt.Errorf("WWW-Authenticate header missing realm: %q", header)
+	}
+	return realm, service, scope, nil
+}
+
+// imageReference is an image name split into its registry host, repository
+// path, and tag or digest reference.
+type imageReference struct {
+	host       string
+	repository string
+	reference  string
+	isDigest   bool
+}
+
+// parseImageReference splits image into an imageReference, defaulting to
+// defaultRegistryHost and the "latest" tag the same way `docker pull` would.
+// Unlike `docker pull`, it does not add the "library/" prefix to a
+// single-component repository name on the default registry.
+func parseImageReference(image string) (imageReference, error) {
+	if image == "" {
+		return imageReference{}, fmt.Errorf("image reference is empty")
+	}
+
+	host, rest := defaultRegistryHost, image
+	if slash := strings.Index(image, "/"); slash != -1 {
+		candidate := image[:slash]
+		if strings.ContainsAny(candidate, ".:") || candidate == "localhost" {
+			host, rest = candidate, image[slash+1:]
+		}
+	}
+
+	if at := strings.Index(rest, "@"); at != -1 {
+		return imageReference{host: host, repository: rest[:at], reference: rest[at+1:], isDigest: true}, nil
+	}
+	if colon := strings.LastIndex(rest, ":"); colon != -1 && !strings.Contains(rest[colon:], "/") {
+		return imageReference{host: host, repository: rest[:colon], reference: rest[colon+1:]}, nil
+	}
+	return imageReference{host: host, repository: rest, reference: "latest"}, nil
+}
@@ -0,0 +1,135 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestParseImageReference(t *testing.T) {
+	tests := []struct {
+		image    string
+		expected imageReference
+	}{
+		{
+			image:    "nginx",
+			expected: imageReference{host: defaultRegistryHost, repository: "nginx", reference: "latest"},
+		},
+		{
+			image:    "nginx:1.25",
+			expected: imageReference{host: defaultRegistryHost, repository: "nginx", reference: "1.25"},
+		},
+		{
+			image:    "localhost:5000/team/app:v2",
+			expected: imageReference{host: "localhost:5000", repository: "team/app", reference: "v2"},
+		},
+		{
+			image:    "registry.example.com/team/app@sha256:abcd",
+			expected: imageReference{host: "registry.example.com", repository: "team/app", reference: "sha256:abcd", isDigest: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.image, func(t *testing.T) {
+			got, err := parseImageReference(tt.image)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("Expected %+v, got %+v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestDistributionResolverResolveAlreadyDigest(t *testing.T) {
+	resolver := NewDistributionResolver(nil)
+	image := "registry.example.com/team/app@sha256:deadbeef"
+	resolved, err := resolver.Resolve(context.Background(), image)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if resolved != image {
+		t.Errorf("Expected an already-pinned image to pass through unchanged, got %q", resolved)
+	}
+}
+
+func TestDistributionResolverResolveSuccess(t *testing.T) {
+	registry := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodHead || r.URL.Path != "/v2/team/app/manifests/latest" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Docker-Content-Digest", "sha256:deadbeef")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer registry.Close()
+	host := strings.TrimPrefix(registry.URL, "http://")
+
+	resolver := NewDistributionResolver(registry.Client())
+	resolved, err := resolver.Resolve(context.Background(), host+"/team/app:latest")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := host + "/team/app@sha256:deadbeef"
+	if resolved != expected {
+		t.Errorf("Expected %q, got %q", expected, resolved)
+	}
+}
+
+func TestDistributionResolverResolveWithAuthChallenge(t *testing.T) {
+	var tokenRequests, manifestRequests int
+	var registry *httptest.Server
+	registry = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/token":
+			tokenRequests++
+			if r.URL.Query().Get("scope") != "repository:team/app:pull" {
+				t.Errorf("Expected token request to carry the challenge's scope, got %q", r.URL.Query().Get("scope"))
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"token":"test-token"}`))
+		case strings.HasPrefix(r.URL.Path, "/v2/"):
+			manifestRequests++
+			if r.Header.Get("Authorization") != "Bearer test-token" {
+				w.Header().Set("Www-Authenticate", `Bearer realm="`+registry.URL+`/token",service="test-registry",scope="repository:team/app:pull"`)
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.Header().Set("Docker-Content-Digest", "sha256:cafef00d")
+			w.WriteHeader(http.StatusOK)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer registry.Close()
+	host := strings.TrimPrefix(registry.URL, "http://")
+
+	resolver := NewDistributionResolver(registry.Client())
+	resolved, err := resolver.Resolve(context.Background(), host+"/team/app:latest")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := host + "/team/app@sha256:cafef00d"
+	if resolved != expected {
+		t.Errorf("Expected %q, got %q", expected, resolved)
+	}
+	if tokenRequests != 1 || manifestRequests != 2 {
+		t.Errorf("Expected 1 token request and 2 manifest requests (challenge then authenticated), got %d and %d", tokenRequests, manifestRequests)
+	}
+}
+
+func TestDistributionResolverResolveManifestNotFound(t *testing.T) {
+	registry := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.NotFound(w, r)
+	}))
+	defer registry.Close()
+	host := strings.TrimPrefix(registry.URL, "http://")
+
+	resolver := NewDistributionResolver(registry.Client())
+	if _, err := resolver.Resolve(context.Background(), host+"/team/missing:latest"); err == nil {
+		t.Error("Expected an error when the manifest does not exist")
+	}
+}
@@ -0,0 +1,266 @@
+// apps/spotter-manager/internal/status.go
+
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/tools/cache"
+)
+
+// statusResyncPeriod controls how often the informer re-lists RayServices
+// as a correctness backstop between watch events.
+const statusResyncPeriod = 30 * time.Second
+
+// statusSubscriberBuffer bounds how many unconsumed updates a /status/watch
+// client can fall behind by before updates are dropped for that client.
+const statusSubscriberBuffer = 8
+
+// StatusCache watches `ray.io/v1alpha1 rayservices` via a shared dynamic
+// informer and serves the latest known object from an in-memory cache, so
+// the /status handlers never need to make a live API call per request.
+type StatusCache struct {
+	factory  dynamicinformer.DynamicSharedInformerFactory
+	informer cache.SharedIndexInformer
+
+	mu      sync.RWMutex
+	objects map[string]*unstructured.Unstructured
+
+	subsMu sync.Mutex
+	subs   map[chan *unstructured.Unstructured]struct{}
+}
+
+// NewStatusCache builds a StatusCache backed by client. Call Start to begin
+// watching; reads against an unstarted cache simply report "not found".
+func NewStatusCache(client dynamic.Interface) *StatusCache {
+	c := &StatusCache{
+		objects: make(map[string]*unstructured.Unstructured),
+		subs:    make(map[chan *unstructured.Unstructured]struct{}),
+	}
+
+	c.factory = dynamicinformer.NewDynamicSharedInformerFactory(client, statusResyncPeriod)
+	c.informer = c.factory.ForResource(rayServiceGVR).Informer()
+	c.informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj interface{}) { c.put(obj) },
+		UpdateFunc: func(_, newObj interface{}) { c.put(newObj) },
+		DeleteFunc: func(obj interface{}) { c.remove(obj) },
+	})
+
+	return c
+}
+
+// Start begins the informer's watch loop and blocks until the initial list
+// has synced. stopCh should be closed on shutdown to stop the informer.
+func (c *StatusCache) Start(stopCh <-chan struct{}) {
+	c.factory.Start(stopCh)
+	c.factory.WaitForCacheSync(stopCh)
+	logger.Info("RayService status informer cache synced.")
+}
+
+// Get returns the latest cached object for namespace/name, if known.
+func (c *StatusCache) Get(namespace, name string) (*unstructured.Unstructured, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	obj, ok := c.objects[cacheKey(namespace, name)]
+	return obj, ok
+}
+
+// Subscribe returns a channel that receives every subsequent add or update
+// the informer observes, and a cancel function that must be called to
+// release it. Deletions are not sent, and an update is dropped for this
+// subscriber while statusSubscriberBuffer updates are already waiting on
+// the channel. The channel is closed by cancel.
+func (c *StatusCache) Subscribe() (ch chan *unstructured.Unstructured, cancel func()) {
+	ch = make(chan *unstructured.Unstructured, statusSubscriberBuffer)
+	c.subsMu.Lock()
+	c.subs[ch] = struct{}{}
+	c.subsMu.Unlock()
+
+	return ch, func() {
+		c.subsMu.Lock()
+		delete(c.subs, ch)
+		c.subsMu.Unlock()
+		close(ch)
+	}
+}
+
+func (c *StatusCache) put(obj interface{}) {
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return
+	}
+	c.mu.Lock()
+	c.objects[cacheKey(u.GetNamespace(), u.GetName())] = u
+	c.mu.Unlock()
+	c.broadcast(u)
+}
+
+func (c *StatusCache) remove(obj interface{}) {
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			return
+		}
+		u, ok = tombstone.Obj.(*unstructured.Unstructured)
+		if !ok {
+			return
+		}
+	}
+	c.mu.Lock()
+	delete(c.objects, cacheKey(u.GetNamespace(), u.GetName()))
+	c.mu.Unlock()
+}
+
+func (c *StatusCache) broadcast(u *unstructured.Unstructured) {
+	c.subsMu.Lock()
+	defer c.subsMu.Unlock()
+	for ch := range c.subs {
+		select {
+		case ch <- u:
+		default:
+			// Slow subscriber; drop the update rather than blocking the
+			// informer's event loop.
+		}
+	}
+}
+
+func cacheKey(namespace, name string) string {
+	return namespace + "/" + name
+}
+
+// StatusResponse reports a RayService's phase, head-pod readiness, Ray
+// Serve application status, and endpoint URL so the frontend can poll
+// after deploy instead of blindly hitting /detect.
+type StatusResponse struct {
+	Name         string `json:"name"`
+	Namespace    string `json:"namespace"`
+	Phase        string `json:"phase,omitempty"`
+	HeadPodReady bool   `json:"headPodReady"`
+	ServeStatus  string `json:"serveStatus,omitempty"`
+	EndpointURL  string `json:"endpointUrl,omitempty"`
+}
+
+// statusResponseFromUnstructured projects a RayService's observed status
+// fields into a StatusResponse.
+func statusResponseFromUnstructured(obj *unstructured.Unstructured) StatusResponse {
+	phase, _, _ := unstructured.NestedString(obj.Object, "status", "rayServiceStatus", "phase")
+	serveStatus, _, _ := unstructured.NestedString(obj.Object, "status", "rayServiceStatus", "serviceStatus")
+	headPodReady, _, _ := unstructured.NestedBool(obj.Object, "status", "rayClusterStatus", "head", "ready")
+
+	return StatusResponse{
+		Name:         obj.GetName(),
+		Namespace:    obj.GetNamespace(),
+		Phase:        phase,
+		HeadPodReady: headPodReady,
+		ServeStatus:  serveStatus,
+		EndpointURL: fmt.Sprintf(
+			"http://%s-head-svc.%s.svc.cluster.local:8000/detect",
+			obj.GetName(),
+			obj.GetNamespace(),
+		),
+	}
+}
+
+// MakeStatusHandler creates an HTTP handler for GET /status. It looks up
+// the RayService named by the required `name` query parameter, in the
+// namespace given by the `namespace` query parameter (defaulting to
+// defaultServiceNamespace), from the informer cache rather than calling the
+// Kubernetes API directly.
+func MakeStatusHandler(statusCache *StatusCache) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		name := r.URL.Query().Get("name")
+		if name == "" {
+			writeError(w, r, http.StatusBadRequest, "Missing required query parameter: name", "Missing required query parameter: name")
+			return
+		}
+		namespace := r.URL.Query().Get("namespace")
+		if namespace == "" {
+			namespace = defaultServiceNamespace
+		}
+
+		obj, ok := statusCache.Get(namespace, name)
+		if !ok {
+			writeError(
+				w, r,
+				http.StatusNotFound,
+				fmt.Sprintf("RayService %s/%s not found in status cache", namespace, name),
+				fmt.Sprintf("RayService '%s' not found in namespace '%s'", name, namespace),
+			)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, statusResponseFromUnstructured(obj))
+	}
+}
+
+// MakeStatusWatchHandler creates an HTTP handler for GET /status/watch that
+// streams StatusResponse updates as text/event-stream whenever the
+// informer observes a change to the named RayService.
+func MakeStatusWatchHandler(statusCache *StatusCache) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		name := r.URL.Query().Get("name")
+		if name == "" {
+			writeError(w, r, http.StatusBadRequest, "Missing required query parameter: name", "Missing required query parameter: name")
+			return
+		}
+		namespace := r.URL.Query().Get("namespace")
+		if namespace == "" {
+			namespace = defaultServiceNamespace
+		}
+
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			writeError(w, r, http.StatusInternalServerError, "Response writer does not support flushing", "Internal server error: streaming unsupported")
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Connection", "keep-alive")
+		w.WriteHeader(http.StatusOK)
+
+		writeEvent := func(obj *unstructured.Unstructured) {
+			payload, err := json.Marshal(statusResponseFromUnstructured(obj))
+			if err != nil {
+				logger.Error("Error marshalling status event",
+					"request_id", requestIDFromContext(r.Context()),
+					"namespace", namespace,
+					"name", name,
+					"error", err.Error(),
+				)
+				return
+			}
+			fmt.Fprintf(w, "data: %s\n\n", payload)
+			flusher.Flush()
+		}
+
+		if obj, ok := statusCache.Get(namespace, name); ok {
+			writeEvent(obj)
+		}
+
+		updates, cancel := statusCache.Subscribe()
+		defer cancel()
+
+		for {
+			select {
+			case obj, ok := <-updates:
+				if !ok {
+					return
+				}
+				if obj.GetNamespace() == namespace && obj.GetName() == name {
+					writeEvent(obj)
+				}
+			case <-r.Context().Done():
+				return
+			}
+		}
+	}
+}
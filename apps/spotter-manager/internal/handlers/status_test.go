@@ -0,0 +1,255 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+// syncResponseRecorder is an http.ResponseWriter/http.Flusher safe for the
+// concurrent reads and writes a streaming SSE handler test requires, unlike
+// httptest.ResponseRecorder's unsynchronized buffer.
+type syncResponseRecorder struct {
+	header http.Header
+
+	mu   sync.Mutex
+	code int
+	body bytes.Buffer
+}
+
+func newSyncResponseRecorder() *syncResponseRecorder {
+	return &syncResponseRecorder{header: make(http.Header), code: http.StatusOK}
+}
+
+func (s *syncResponseRecorder) Header() http.Header { return s.header }
+
+func (s *syncResponseRecorder) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.body.Write(p)
+}
+
+func (s *syncResponseRecorder) WriteHeader(code int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.code = code
+}
+
+func (s *syncResponseRecorder) Flush() {}
+
+func (s *syncResponseRecorder) String() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.body.String()
+}
+
+// waitForEvents polls rr until it has streamed at least n "data: " events or
+// t fails with a timeout.
+func waitForEvents(t *testing.T, rr *syncResponseRecorder, n int) string {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		body := rr.String()
+		if strings.Count(body, "data: ") >= n {
+			return body
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Timed out waiting for %d SSE events, got body %q", n, body)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func newTestRayService(name, namespace, phase string, headReady bool) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion("ray.io/v1alpha1")
+	obj.SetKind("RayService")
+	obj.SetName(name)
+	obj.SetNamespace(namespace)
+	unstructured.SetNestedField(obj.Object, phase, "status", "rayServiceStatus", "phase")
+	unstructured.SetNestedField(obj.Object, headReady, "status", "rayClusterStatus", "head", "ready")
+	return obj
+}
+
+func TestStatusCacheGet(t *testing.T) {
+	existing := newTestRayService("tenant-a", defaultServiceNamespace, "Running", true)
+
+	client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(
+		runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{rayServiceGVR: "RayServiceList"},
+		existing,
+	)
+
+	statusCache := NewStatusCache(client)
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	statusCache.Start(stopCh)
+
+	obj, ok := statusCache.Get(defaultServiceNamespace, "tenant-a")
+	if !ok {
+		t.Fatalf("Expected cached RayService tenant-a, found none")
+	}
+	resp := statusResponseFromUnstructured(obj)
+	if resp.Phase != "Running" || !resp.HeadPodReady {
+		t.Errorf("Unexpected status response: %+v", resp)
+	}
+
+	if _, ok := statusCache.Get(defaultServiceNamespace, "missing"); ok {
+		t.Errorf("Expected no cached entry for 'missing'")
+	}
+}
+
+func TestMakeStatusHandler(t *testing.T) {
+	existing := newTestRayService("tenant-a", defaultServiceNamespace, "Running", true)
+
+	client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(
+		runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{rayServiceGVR: "RayServiceList"},
+		existing,
+	)
+
+	statusCache := NewStatusCache(client)
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	statusCache.Start(stopCh)
+
+	handler := MakeStatusHandler(statusCache)
+
+	t.Run("Found", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodGet, "/status?name=tenant-a", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+
+		if rr.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d. Body: %s", rr.Code, rr.Body.String())
+		}
+	})
+
+	t.Run("Missing Name", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodGet, "/status", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+
+		if rr.Code != http.StatusBadRequest {
+			t.Fatalf("Expected status 400, got %d. Body: %s", rr.Code, rr.Body.String())
+		}
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodGet, "/status?name=does-not-exist", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+
+		if rr.Code != http.StatusNotFound {
+			t.Fatalf("Expected status 404, got %d. Body: %s", rr.Code, rr.Body.String())
+		}
+	})
+}
+
+func TestStatusCacheSubscribe(t *testing.T) {
+	client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(
+		runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{rayServiceGVR: "RayServiceList"},
+	)
+
+	statusCache := NewStatusCache(client)
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	statusCache.Start(stopCh)
+
+	updates, cancel := statusCache.Subscribe()
+	defer cancel()
+
+	created := newTestRayService("tenant-b", defaultServiceNamespace, "Running", true)
+	if _, err := client.Resource(rayServiceGVR).Namespace(defaultServiceNamespace).Create(
+		context.Background(), created, metav1.CreateOptions{},
+	); err != nil {
+		t.Fatalf("Failed to create RayService: %v", err)
+	}
+
+	select {
+	case obj := <-updates:
+		if obj.GetName() != "tenant-b" {
+			t.Errorf("Expected update for tenant-b, got %s", obj.GetName())
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Timed out waiting for status cache update")
+	}
+}
+
+func TestMakeStatusWatchHandler(t *testing.T) {
+	existing := newTestRayService("tenant-a", defaultServiceNamespace, "Pending", false)
+
+	client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(
+		runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{rayServiceGVR: "RayServiceList"},
+		existing,
+	)
+
+	statusCache := NewStatusCache(client)
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	statusCache.Start(stopCh)
+
+	handler := MakeStatusWatchHandler(statusCache)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	req := httptest.NewRequest(http.MethodGet, "/status/watch?name=tenant-a", nil).WithContext(ctx)
+	rr := newSyncResponseRecorder()
+
+	done := make(chan struct{})
+	go func() {
+		handler.ServeHTTP(rr, req)
+		close(done)
+	}()
+
+	body := waitForEvents(t, rr, 1)
+	if !strings.Contains(body, "data: ") || !strings.HasSuffix(body, "\n\n") {
+		t.Errorf("Expected SSE framing (\"data: ...\\n\\n\"), got body %q", body)
+	}
+	if !strings.Contains(body, `"phase":"Pending"`) {
+		t.Errorf("Expected the initial event to carry the cached phase, got body %q", body)
+	}
+	if ct := rr.Header().Get("Content-Type"); ct != "text/event-stream" {
+		t.Errorf("Expected Content-Type text/event-stream, got %q", ct)
+	}
+
+	updated := newTestRayService("tenant-a", defaultServiceNamespace, "Running", true)
+	if _, err := client.Resource(rayServiceGVR).Namespace(defaultServiceNamespace).Update(
+		context.Background(), updated, metav1.UpdateOptions{},
+	); err != nil {
+		t.Fatalf("Failed to update RayService: %v", err)
+	}
+
+	body = waitForEvents(t, rr, 2)
+	if !strings.Contains(body, `"phase":"Running"`) {
+		t.Errorf("Expected a second event reflecting the update, got body %q", body)
+	}
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Timed out waiting for the handler to return after context cancellation")
+	}
+}
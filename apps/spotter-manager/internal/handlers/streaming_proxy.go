@@ -0,0 +1,130 @@
+// apps/spotter-manager/internal/streaming_proxy.go
+
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	streamingProxyHandshakeTimeout = 10 * time.Second
+	streamingProxyCloseWriteWait   = 10 * time.Second
+)
+
+// streamingUpgrader upgrades incoming client connections. Origin checking is
+// left to whatever ingress/proxy spotter-manager sits behind rather than
+// duplicated here.
+var streamingUpgrader = websocket.Upgrader{
+	CheckOrigin: func(r *http.Request) bool { return true },
+}
+
+// StreamingProxyHandler upgrades the client connection to a WebSocket and
+// bridges text and binary frames to and from the equivalent endpoint on the
+// RayService Serve backend, so long-running or incremental detections (e.g.
+// video) can stream results back instead of waiting for a single response.
+type StreamingProxyHandler struct {
+	TargetURL string
+}
+
+// NewStreamingProxyHandler creates a StreamingProxyHandler targeting
+// targetURLOverride, or the default RayService streaming detection endpoint
+// if empty.
+func NewStreamingProxyHandler(targetURLOverride string) *StreamingProxyHandler {
+	targetURL := targetURLOverride
+	if targetURL == "" {
+		targetURL = fmt.Sprintf(
+			"ws://spotter-ray-service-head-svc.%s.svc.cluster.local:8000/detect/stream",
+			defaultServiceNamespace,
+		)
+	}
+	return &StreamingProxyHandler{TargetURL: targetURL}
+}
+
+// ServeHTTP upgrades r to a WebSocket, dials the same upgrade against
+// h.TargetURL (propagating a handful of request-scoped headers), and pumps
+// frames between the two connections until either side closes or r's
+// context is canceled, at which point both connections are closed.
+func (h *StreamingProxyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !websocket.IsWebSocketUpgrade(r) {
+		http.Error(w, "This endpoint requires a WebSocket upgrade.", http.StatusMethodNotAllowed)
+		return
+	}
+
+	clientConn, err := streamingUpgrader.Upgrade(w, r, nil)
+	if err != nil {
+		logger.Error("Error upgrading client connection",
+			"request_id", requestIDFromContext(r.Context()),
+			"error", err.Error(),
+		)
+		return
+	}
+	defer clientConn.Close()
+
+	backendHeader := make(http.Header)
+	for _, key := range []string{"Authorization", requestIDHeader, "Cookie"} {
+		if v := r.Header.Get(key); v != "" {
+			backendHeader.Set(key, v)
+		}
+	}
+
+	dialer := websocket.Dialer{HandshakeTimeout: streamingProxyHandshakeTimeout}
+	backendConn, _, err := dialer.DialContext(r.Context(), h.TargetURL, backendHeader)
+	if err != nil {
+		logger.Error("Error dialing RayService streaming backend",
+			"request_id", requestIDFromContext(r.Context()),
+			"target_url", h.TargetURL,
+			"error", err.Error(),
+		)
+		clientConn.WriteControl(
+			websocket.CloseMessage,
+			websocket.FormatCloseMessage(websocket.CloseInternalServerErr, fmt.Sprintf("bad gateway: %v", err)),
+			time.Now().Add(streamingProxyCloseWriteWait),
+		)
+		return
+	}
+	defer backendConn.Close()
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	errs := make(chan error, 2)
+	go pumpFrames(ctx, clientConn, backendConn, errs)
+	go pumpFrames(ctx, backendConn, clientConn, errs)
+
+	select {
+	case err := <-errs:
+		if err != nil && !websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+			logger.Error("Streaming proxy connection closed with error",
+				"request_id", requestIDFromContext(r.Context()),
+				"error", err.Error(),
+			)
+		}
+	case <-ctx.Done():
+	}
+}
+
+// pumpFrames copies every text/binary frame read from src to dst until src
+// closes, ctx is canceled, or a write to dst fails. The failing read or write
+// error is sent to errs (a *websocket.CloseError when src closes, even
+// cleanly); nothing is sent if it stops because ctx was canceled.
+func pumpFrames(ctx context.Context, src, dst *websocket.Conn, errs chan<- error) {
+	for {
+		messageType, payload, err := src.ReadMessage()
+		if err != nil {
+			errs <- err
+			return
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		if err := dst.WriteMessage(messageType, payload); err != nil {
+			errs <- err
+			return
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestStreamingProxyHandlerEchoRoundTrip(t *testing.T) {
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := streamingUpgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("Backend failed to upgrade: %v", err)
+			return
+		}
+		defer conn.Close()
+		for {
+			messageType, payload, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			if err := conn.WriteMessage(messageType, payload); err != nil {
+				return
+			}
+		}
+	}))
+	defer backend.Close()
+	backendURL := "ws" + strings.TrimPrefix(backend.URL, "http")
+
+	proxyServer := httptest.NewServer(NewStreamingProxyHandler(backendURL))
+	defer proxyServer.Close()
+	proxyURL := "ws" + strings.TrimPrefix(proxyServer.URL, "http")
+
+	clientConn, _, err := websocket.DefaultDialer.Dial(proxyURL, nil)
+	if err != nil {
+		t.Fatalf("Failed to dial streaming proxy: %v", err)
+	}
+	defer clientConn.Close()
+
+	if err := clientConn.WriteMessage(websocket.TextMessage, []byte("hello")); err != nil {
+		t.Fatalf("Failed to write text frame: %v", err)
+	}
+	clientConn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	messageType, payload, err := clientConn.ReadMessage()
+	if err != nil {
+		t.Fatalf("Failed to read echoed text frame: %v", err)
+	}
+	if messageType != websocket.TextMessage || string(payload) != "hello" {
+		t.Errorf("Expected echoed text frame 'hello', got type %d payload %q", messageType, payload)
+	}
+
+	binaryPayload := []byte{0x01, 0x02, 0x03}
+	if err := clientConn.WriteMessage(websocket.BinaryMessage, binaryPayload); err != nil {
+		t.Fatalf("Failed to write binary frame: %v", err)
+	}
+	clientConn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	messageType, payload, err = clientConn.ReadMessage()
+	if err != nil {
+		t.Fatalf("Failed to read echoed binary frame: %v", err)
+	}
+	if messageType != websocket.BinaryMessage || string(payload) != string(binaryPayload) {
+		t.Errorf("Expected echoed binary frame %v, got type %d payload %v", binaryPayload, messageType, payload)
+	}
+}
+
+func TestStreamingProxyHandlerBackendDown(t *testing.T) {
+	proxyServer := httptest.NewServer(NewStreamingProxyHandler("ws://localhost:9999/nonexistent"))
+	defer proxyServer.Close()
+	proxyURL := "ws" + strings.TrimPrefix(proxyServer.URL, "http")
+
+	clientConn, _, err := websocket.DefaultDialer.Dial(proxyURL, nil)
+	if err != nil {
+		t.Fatalf("Failed to dial streaming proxy: %v", err)
+	}
+	defer clientConn.Close()
+
+	clientConn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	_, _, err = clientConn.ReadMessage()
+	closeErr, ok := err.(*websocket.CloseError)
+	if !ok {
+		t.Fatalf("Expected a WebSocket close error when the backend is unreachable, got %v", err)
+	}
+	if closeErr.Code != websocket.CloseInternalServerErr {
+		t.Errorf("Expected close code %d, got %d", websocket.CloseInternalServerErr, closeErr.Code)
+	}
+}
+
+func TestStreamingProxyHandlerWrongMethod(t *testing.T) {
+	handler := NewStreamingProxyHandler("ws://localhost:9999/unused")
+
+	req, err := http.NewRequest(http.MethodGet, "/detect/stream", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected status %d for a non-upgrade request, got %d", http.StatusMethodNotAllowed, rr.Code)
+	}
+}
@@ -0,0 +1,345 @@
+// apps/spotter-manager/internal/templates.go
+
+package handlers
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"text/template"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/dynamic"
+)
+
+// TemplateParameter describes one named value a Template's manifest body
+// references, so GET /templates can publish a schema and
+// MakeTemplateDeployHandler can validate a request before rendering.
+type TemplateParameter struct {
+	Name     string `json:"name"`
+	Required bool   `json:"required"`
+}
+
+// Template is a pluggable deployment manifest: a Go-template YAML body
+// targeting a specific GVR, parameterized so the same render-and-apply path
+// can deploy RayServices, plain Deployments, or any other resource kind an
+// operator registers, instead of one handler per kind.
+type Template struct {
+	Name       string
+	GVR        schema.GroupVersionResource
+	Namespaced bool
+	Parameters []TemplateParameter
+	Body       string
+}
+
+// Render validates that every required parameter is present in values, then
+// populates Body with them and decodes the result into an unstructured
+// object.
+func (t Template) Render(values map[string]string) (*unstructured.Unstructured, error) {
+	for _, p := range t.Parameters {
+		if p.Required && values[p.Name] == "" {
+			return nil, fmt.Errorf("missing required parameter: %s", p.Name)
+		}
+	}
+
+	tmpl, err := template.New(t.Name).Parse(t.Body)
+	if err != nil {
+		return nil, fmt.Errorf("parsing template %q: %w", t.Name, err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, values); err != nil {
+		return nil, fmt.Errorf("rendering template %q: %w", t.Name, err)
+	}
+
+	obj := &unstructured.Unstructured{}
+	decoder := yaml.NewYAMLOrJSONDecoder(bytes.NewReader(buf.Bytes()), 4096)
+	if err := decoder.Decode(&obj); err != nil {
+		return nil, fmt.Errorf("decoding rendered template %q: %w\nYAML:\n%s", t.Name, err, buf.String())
+	}
+	return obj, nil
+}
+
+// TemplateRegistry holds the set of Templates MakeTemplateDeployHandler may
+// render and apply, keyed by name.
+type TemplateRegistry struct {
+	mu        sync.RWMutex
+	templates map[string]Template
+}
+
+// NewTemplateRegistry creates a TemplateRegistry seeded with templates.
+func NewTemplateRegistry(templates ...Template) *TemplateRegistry {
+	r := &TemplateRegistry{templates: make(map[string]Template, len(templates))}
+	for _, t := range templates {
+		r.templates[t.Name] = t
+	}
+	return r
+}
+
+// Get returns the named Template, if registered.
+func (r *TemplateRegistry) Get(name string) (Template, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	t, ok := r.templates[name]
+	return t, ok
+}
+
+// List returns every registered Template sorted by name, for GET /templates.
+func (r *TemplateRegistry) List() []Template {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	list := make([]Template, 0, len(r.templates))
+	for _, t := range r.templates {
+		list = append(list, t)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list
+}
+
+// LoadTemplatesFromDir reads every *.yaml.tmpl file in dir into a Template.
+// Each file begins with a header of `# key: value` comment lines (name, gvr,
+// namespaced, params) followed by the Go-template YAML manifest body: gvr is
+// formatted as `group/version/resource` and params is a comma-separated list
+// of parameter names, suffixed with `*` for required ones, e.g.
+// `# params: name*,namespace,image*,replicas`.
+func LoadTemplatesFromDir(dir string) ([]Template, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("reading template directory %q: %w", dir, err)
+	}
+
+	var templates []Template
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".yaml.tmpl") {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading template file %q: %w", path, err)
+		}
+		t, err := parseTemplateFile(content)
+		if err != nil {
+			return nil, fmt.Errorf("parsing template file %q: %w", path, err)
+		}
+		templates = append(templates, t)
+	}
+	return templates, nil
+}
+
+// parseTemplateFile splits a template file into its `# key: value` header
+// and Go-template YAML body.
+func parseTemplateFile(content []byte) (Template, error) {
+	var t Template
+	lines := strings.Split(string(content), "\n")
+
+	i := 0
+	for ; i < len(lines); i++ {
+		line := strings.TrimSpace(lines[i])
+		if !strings.HasPrefix(line, "#") {
+			break
+		}
+		key, value, ok := strings.Cut(strings.TrimSpace(strings.TrimPrefix(line, "#")), ":")
+		if !ok {
+			continue
+		}
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		switch key {
+		case "name":
+			t.Name = value
+		case "gvr":
+			parts := strings.Split(value, "/")
+			if len(parts) != 3 {
+				return Template{}, fmt.Errorf("gvr must be group/version/resource, got %q", value)
+			}
+			t.GVR = schema.GroupVersionResource{Group: parts[0], Version: parts[1], Resource: parts[2]}
+		case "namespaced":
+			t.Namespaced = value == "true"
+		case "params":
+			for _, p := range strings.Split(value, ",") {
+				if p == "" {
+					continue
+				}
+				t.Parameters = append(t.Parameters, TemplateParameter{
+					Name:     strings.TrimSuffix(p, "*"),
+					Required: strings.HasSuffix(p, "*"),
+				})
+			}
+		}
+	}
+	if t.Name == "" {
+		return Template{}, fmt.Errorf("template is missing a required '# name: ...' header line")
+	}
+	t.Body = strings.Join(lines[i:], "\n")
+	return t, nil
+}
+
+// templateInfo is the JSON representation of a Template returned by GET
+// /templates; Body is omitted since it is an implementation detail.
+type templateInfo struct {
+	Name       string              `json:"name"`
+	GVR        string              `json:"gvr"`
+	Namespaced bool                `json:"namespaced"`
+	Parameters []TemplateParameter `json:"parameters"`
+}
+
+// TemplateDeployResponse is the JSON response from a successful
+// template-driven deploy.
+type TemplateDeployResponse struct {
+	Template  string `json:"template"`
+	Kind      string `json:"kind,omitempty"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
+	UID       string `json:"uid,omitempty"`
+}
+
+// MakeListTemplatesHandler creates an HTTP handler for GET /templates. It
+// lists every registered Template along with its declared parameter schema.
+func MakeListTemplatesHandler(registry *TemplateRegistry) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		templates := registry.List()
+		infos := make([]templateInfo, 0, len(templates))
+		for _, t := range templates {
+			infos = append(infos, templateInfo{
+				Name:       t.Name,
+				GVR:        t.GVR.String(),
+				Namespaced: t.Namespaced,
+				Parameters: t.Parameters,
+			})
+		}
+		writeJSON(w, http.StatusOK, struct {
+			Items []templateInfo `json:"items"`
+		}{Items: infos})
+	}
+}
+
+// MakeTemplateDeployHandler creates an HTTP handler for
+// POST /deploy?template=<name>&<param>=<value>...&cluster=<name>. It renders
+// the named Template against its query-parameter values and server-side
+// applies the result, so operators can deploy any registered resource kind
+// through one endpoint instead of a handler per kind. If the request
+// supplies a non-empty "image" parameter, it is resolved to an immutable
+// `repository@sha256:...` reference via images before rendering; images may
+// be nil to skip resolution entirely. Every apply attempt, successful or
+// not, is recorded via audit.
+func MakeTemplateDeployHandler(registry *TemplateRegistry, clusters *ClusterRegistry, images ImageResolver, audit AuditStore) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		name := query.Get("template")
+		if name == "" {
+			writeError(w, r, http.StatusBadRequest, "Missing required query parameter: template", "Missing required query parameter: template")
+			return
+		}
+		tmpl, ok := registry.Get(name)
+		if !ok {
+			writeError(w, r, http.StatusNotFound, fmt.Sprintf("Unknown template: %s", name), fmt.Sprintf("unknown template '%s'", name))
+			return
+		}
+
+		values := make(map[string]string, len(query))
+		for key, vals := range query {
+			if key == "template" || key == "cluster" || len(vals) == 0 {
+				continue
+			}
+			values[key] = vals[0]
+		}
+		if tmpl.Namespaced && values["namespace"] == "" {
+			values["namespace"] = defaultServiceNamespace
+		}
+
+		if images != nil && values["image"] != "" {
+			resolved, err := images.Resolve(r.Context(), values["image"])
+			if err != nil {
+				writeError(
+					w, r,
+					http.StatusBadRequest,
+					fmt.Sprintf("Error resolving image %q: %v", values["image"], err),
+					fmt.Sprintf("failed to resolve image '%s': %s", values["image"], err.Error()),
+				)
+				return
+			}
+			values["image"] = resolved
+		}
+
+		obj, err := tmpl.Render(values)
+		if err != nil {
+			writeError(w, r, http.StatusBadRequest, fmt.Sprintf("Error rendering template %q: %v", name, err), err.Error())
+			return
+		}
+
+		client, err := clusters.Get(r.Context(), query.Get("cluster"))
+		if err != nil {
+			writeError(
+				w, r,
+				http.StatusBadGateway,
+				fmt.Sprintf("Error resolving target cluster %q: %v", query.Get("cluster"), err),
+				fmt.Sprintf("failed to reach target cluster: %s", err.Error()),
+			)
+			return
+		}
+
+		var resourceClient dynamic.ResourceInterface = client.Resource(tmpl.GVR)
+		if tmpl.Namespaced {
+			resourceClient = client.Resource(tmpl.GVR).Namespace(values["namespace"])
+		}
+
+		repository, digest := splitImageDigest(values["image"])
+
+		applied, err := resourceClient.Apply(r.Context(), obj.GetName(), obj, metav1.ApplyOptions{
+			FieldManager: "spotter-manager",
+			Force:        true,
+		})
+		if err != nil {
+			writeAuditRecord(r, audit, AuditRecord{
+				Action:    "deploy",
+				Template:  name,
+				Image:     repository,
+				Digest:    digest,
+				Name:      obj.GetName(),
+				Namespace: values["namespace"],
+				Success:   false,
+				Error:     err.Error(),
+			})
+			writeKubernetesError(
+				w, r,
+				http.StatusInternalServerError,
+				fmt.Sprintf("Error applying template %q: %v", name, err),
+				fmt.Sprintf("failed to apply template '%s': %s", name, err.Error()),
+				err,
+			)
+			return
+		}
+
+		logger.Info("Successfully applied templated resource",
+			"request_id", requestIDFromContext(r.Context()),
+			"template", name,
+			"kind", applied.GetKind(),
+			"namespace", applied.GetNamespace(),
+			"name", applied.GetName(),
+		)
+		writeAuditRecord(r, audit, AuditRecord{
+			UID:       string(applied.GetUID()),
+			Action:    "deploy",
+			Template:  name,
+			Image:     repository,
+			Digest:    digest,
+			Name:      applied.GetName(),
+			Namespace: applied.GetNamespace(),
+			Success:   true,
+		})
+		writeJSON(w, http.StatusOK, TemplateDeployResponse{
+			Template:  name,
+			Kind:      applied.GetKind(),
+			Name:      applied.GetName(),
+			Namespace: applied.GetNamespace(),
+			UID:       string(applied.GetUID()),
+		})
+	}
+}
@@ -0,0 +1,212 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	kubetesting "k8s.io/client-go/testing"
+)
+
+const rayServiceTemplateBody = `# name: rayservice
+# gvr: ray.io/v1alpha1/rayservices
+# namespaced: true
+# params: name*,namespace,image*
+apiVersion: ray.io/v1alpha1
+kind: RayService
+metadata:
+  name: {{.name}}
+  namespace: {{.namespace}}
+spec:
+  rayClusterConfig:
+    headGroupSpec:
+      template:
+        spec:
+          containers:
+            - name: ray-head
+              image: {{.image}}
+`
+
+const deploymentTemplateBody = `# name: deployment
+# gvr: apps/v1/deployments
+# namespaced: true
+# params: name*,namespace,image*
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: {{.name}}
+  namespace: {{.namespace}}
+spec:
+  template:
+    spec:
+      containers:
+        - name: app
+          image: {{.image}}
+`
+
+func TestParseTemplateFile(t *testing.T) {
+	tmpl, err := parseTemplateFile([]byte(rayServiceTemplateBody))
+	if err != nil {
+		t.Fatalf("Unexpected error parsing template: %v", err)
+	}
+	if tmpl.Name != "rayservice" {
+		t.Errorf("Expected name 'rayservice', got %q", tmpl.Name)
+	}
+	wantGVR := schema.GroupVersionResource{Group: "ray.io", Version: "v1alpha1", Resource: "rayservices"}
+	if tmpl.GVR != wantGVR {
+		t.Errorf("Expected GVR %+v, got %+v", wantGVR, tmpl.GVR)
+	}
+	if !tmpl.Namespaced {
+		t.Error("Expected namespaced to be true")
+	}
+	if len(tmpl.Parameters) != 3 || !tmpl.Parameters[0].Required || tmpl.Parameters[0].Name != "name" {
+		t.Errorf("Unexpected parameters: %+v", tmpl.Parameters)
+	}
+}
+
+func TestTemplateRenderMissingRequiredParameter(t *testing.T) {
+	tmpl, err := parseTemplateFile([]byte(rayServiceTemplateBody))
+	if err != nil {
+		t.Fatalf("Unexpected error parsing template: %v", err)
+	}
+	if _, err := tmpl.Render(map[string]string{"namespace": "spotter"}); err == nil {
+		t.Error("Expected an error when required parameters are missing")
+	}
+}
+
+func TestMakeTemplateDeployHandler(t *testing.T) {
+	rayTemplate, err := parseTemplateFile([]byte(rayServiceTemplateBody))
+	if err != nil {
+		t.Fatalf("Unexpected error parsing rayservice template: %v", err)
+	}
+	deploymentTemplate, err := parseTemplateFile([]byte(deploymentTemplateBody))
+	if err != nil {
+		t.Fatalf("Unexpected error parsing deployment template: %v", err)
+	}
+	registry := NewTemplateRegistry(rayTemplate, deploymentTemplate)
+
+	tests := []struct {
+		name               string
+		queryString        string
+		expectedStatusCode int
+		expectedKind       string
+		expectedGVR        schema.GroupVersionResource
+	}{
+		{
+			name:               "Deploys a RayService",
+			queryString:        "?template=rayservice&name=tenant-a&image=test-image:latest",
+			expectedStatusCode: http.StatusOK,
+			expectedKind:       "RayService",
+			expectedGVR:        rayTemplate.GVR,
+		},
+		{
+			name:               "Deploys a plain Deployment",
+			queryString:        "?template=deployment&name=tenant-a&image=test-image:latest",
+			expectedStatusCode: http.StatusOK,
+			expectedKind:       "Deployment",
+			expectedGVR:        deploymentTemplate.GVR,
+		},
+		{
+			name:               "Error - Unknown Template",
+			queryString:        "?template=nonexistent&name=tenant-a",
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			name:               "Error - Missing Required Parameter",
+			queryString:        "?template=rayservice&name=tenant-a",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme())
+			fakeClient.PrependReactor("patch", "*", func(action kubetesting.Action) (handled bool, ret runtime.Object, err error) {
+				patchAction, ok := action.(kubetesting.PatchAction)
+				if !ok || patchAction.GetPatchType() != types.ApplyPatchType {
+					return false, nil, nil
+				}
+				retObj := &unstructured.Unstructured{}
+				if err := json.Unmarshal(patchAction.GetPatch(), &retObj.Object); err != nil {
+					return true, nil, fmt.Errorf("failed to unmarshal apply patch in reactor: %w", err)
+				}
+				retObj.SetName(patchAction.GetName())
+				retObj.SetNamespace(patchAction.GetNamespace())
+				retObj.SetUID("test-uid-123")
+				return true, retObj, nil
+			})
+
+			handler := MakeTemplateDeployHandler(registry, NewClusterRegistry(fakeClient, nil), nil, NewMemoryAuditStore())
+
+			req, err := http.NewRequest(http.MethodPost, "/deploy"+tt.queryString, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			if rr.Code != tt.expectedStatusCode {
+				t.Fatalf("Expected status code %d, got %d. Body: %s", tt.expectedStatusCode, rr.Code, rr.Body.String())
+			}
+			if tt.expectedStatusCode != http.StatusOK {
+				return
+			}
+
+			var resp TemplateDeployResponse
+			if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("Failed to unmarshal response body: %v\nBody: %s", err, rr.Body.Bytes())
+			}
+			if resp.Kind != tt.expectedKind {
+				t.Errorf("Expected kind %q, got %q", tt.expectedKind, resp.Kind)
+			}
+
+			actions := fakeClient.Actions()
+			if len(actions) != 1 {
+				t.Fatalf("Expected 1 K8s action, got %d", len(actions))
+			}
+			patchAction, ok := actions[0].(kubetesting.PatchAction)
+			if !ok {
+				t.Fatalf("Expected PatchAction, got %T", actions[0])
+			}
+			if patchAction.GetResource() != tt.expectedGVR {
+				t.Errorf("Expected apply against GVR %+v, got %+v", tt.expectedGVR, patchAction.GetResource())
+			}
+		})
+	}
+}
+
+func TestMakeListTemplatesHandler(t *testing.T) {
+	rayTemplate, err := parseTemplateFile([]byte(rayServiceTemplateBody))
+	if err != nil {
+		t.Fatalf("Unexpected error parsing template: %v", err)
+	}
+	registry := NewTemplateRegistry(rayTemplate)
+	handler := MakeListTemplatesHandler(registry)
+
+	req, err := http.NewRequest(http.MethodGet, "/templates", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", rr.Code, rr.Body.String())
+	}
+	var body struct {
+		Items []templateInfo `json:"items"`
+	}
+	if err := json.Unmarshal(rr.Body.Bytes(), &body); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if len(body.Items) != 1 || body.Items[0].Name != "rayservice" {
+		t.Errorf("Expected one template named 'rayservice', got %+v", body.Items)
+	}
+}